@@ -0,0 +1,391 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// Function types of a json ABI
+const (
+	FUNCTION    = "function"
+	CONSTRUCTOR = "constructor"
+	FALLBACK    = "fallback"
+	RECEIVE     = "receive"
+)
+
+// Method is a function, or the constructor, fallback or receive function
+// of a contract.
+type Method struct {
+	Type            string // FUNCTION, CONSTRUCTOR, FALLBACK or RECEIVE
+	Name            string
+	Inputs          Arguments
+	Outputs         Arguments
+	StateMutability string // pure, view, nonpayable or payable
+	Signature       string // canonical signature, as hashed into the selector
+	Selector        [4]byte
+}
+
+// Event is an event a contract logs.
+type Event struct {
+	Name      string
+	Inputs    Arguments
+	Anonymous bool
+	Signature string
+	Topic     types.Hash // first topic of its logs, unless anonymous
+}
+
+// Error is a custom error a contract reverts with.
+type Error struct {
+	Name      string
+	Inputs    Arguments
+	Signature string
+	Selector  [4]byte
+}
+
+// ABI is the interface of a contract. Overloaded functions, events and
+// errors share their name, the signature tells them apart.
+type ABI struct {
+	Constructor *Method
+	Fallback    *Method
+	Receive     *Method
+	Methods     []Method
+	Events      []Event
+	Errors      []Error
+}
+
+// jsonArgument is an input or output entry of a json ABI.
+type jsonArgument struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Indexed    bool           `json:"indexed,omitempty"`
+	Components []jsonArgument `json:"components,omitempty"`
+}
+
+type jsonEntry struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []jsonArgument `json:"inputs"`
+	Outputs         []jsonArgument `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Anonymous       bool           `json:"anonymous"`
+	// before solidity 0.4.16
+	Constant bool `json:"constant"`
+	Payable  bool `json:"payable"`
+}
+
+// ParseJSON reads a json ABI, as output by solc. A json object with an
+// "abi" field, as written by hardhat and foundry, is read as well.
+func ParseJSON(data []byte) (*ABI, error) {
+	var entries []jsonEntry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var artifact struct {
+			Abi []jsonEntry `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("invalid abi json: %w", err)
+		}
+		entries = artifact.Abi
+	} else if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid abi json: %w", err)
+	}
+
+	a := &ABI{}
+	for _, entry := range entries {
+		inputs, err := jsonArguments(entry.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", entry.Type, entry.Name, err)
+		}
+		switch entry.Type {
+		case "event":
+			a.Events = append(a.Events, NewEvent(entry.Name, inputs, entry.Anonymous))
+		case "error":
+			a.Errors = append(a.Errors, NewError(entry.Name, inputs))
+		case FUNCTION, "", CONSTRUCTOR, FALLBACK, RECEIVE:
+			outputs, err := jsonArguments(entry.Outputs)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", entry.Type, entry.Name, err)
+			}
+			mutability := entry.StateMutability
+			if mutability == "" {
+				switch {
+				case entry.Constant:
+					mutability = "view"
+				case entry.Payable:
+					mutability = "payable"
+				default:
+					mutability = "nonpayable"
+				}
+			}
+			kind := entry.Type
+			if kind == "" {
+				kind = FUNCTION
+			}
+			if err := a.addMethod(NewMethod(kind, entry.Name, inputs, outputs, mutability)); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown abi entry type %q", entry.Type)
+		}
+	}
+	return a, nil
+}
+
+func jsonArguments(entries []jsonArgument) (Arguments, error) {
+	args := make(Arguments, len(entries))
+	for i, e := range entries {
+		t, err := newJsonType(e.Type, e.Components)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = Argument{Name: e.Name, Type: t, Indexed: e.Indexed}
+	}
+	return args, nil
+}
+
+func (a *ABI) addMethod(m Method) error {
+	special := map[string]**Method{CONSTRUCTOR: &a.Constructor, FALLBACK: &a.Fallback, RECEIVE: &a.Receive}
+	if slot, ok := special[m.Type]; ok {
+		if *slot != nil {
+			return fmt.Errorf("abi with more than one %s", m.Type)
+		}
+		*slot = &m
+		return nil
+	}
+	a.Methods = append(a.Methods, m)
+	return nil
+}
+
+// NewMethod returns a method with its signature and selector.
+func NewMethod(kind string, name string, inputs Arguments, outputs Arguments, mutability string) Method {
+	m := Method{Type: kind, Name: name, Inputs: inputs, Outputs: outputs, StateMutability: mutability}
+	if kind == FUNCTION {
+		m.Signature = fmt.Sprintf("%s(%s)", name, inputs)
+		copy(m.Selector[:], keccak256([]byte(m.Signature)))
+	}
+	return m
+}
+
+// NewEvent returns an event with its signature and topic.
+func NewEvent(name string, inputs Arguments, anonymous bool) Event {
+	e := Event{Name: name, Inputs: inputs, Anonymous: anonymous, Signature: fmt.Sprintf("%s(%s)", name, inputs)}
+	e.Topic = types.BytesToHash(keccak256([]byte(e.Signature)))
+	return e
+}
+
+// NewError returns an error with its signature and selector.
+func NewError(name string, inputs Arguments) Error {
+	e := Error{Name: name, Inputs: inputs, Signature: fmt.Sprintf("%s(%s)", name, inputs)}
+	copy(e.Selector[:], keccak256([]byte(e.Signature)))
+	return e
+}
+
+// Selector returns the 4 bytes selector of a signature, such as
+// "transfer(address,uint256)", which must be canonical.
+func Selector(signature string) [4]byte {
+	var sel [4]byte
+	copy(sel[:], keccak256([]byte(signature)))
+	return sel
+}
+
+// Method returns the function called name, or with this signature when
+// overloaded.
+func (a *ABI) Method(name string) (Method, error) {
+	var found []Method
+	for _, m := range a.Methods {
+		if m.Signature == name {
+			return m, nil
+		}
+		if m.Name == name {
+			found = append(found, m)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Method{}, fmt.Errorf("no function %s in abi", name)
+	case 1:
+		return found[0], nil
+	}
+	return Method{}, fmt.Errorf("function %s is overloaded, give its signature", name)
+}
+
+// MethodBySelector returns the function called by call data, which starts
+// with its selector.
+func (a *ABI) MethodBySelector(data []byte) (Method, error) {
+	if len(data) < 4 {
+		return Method{}, fmt.Errorf("call data of %d bytes without selector", len(data))
+	}
+	for _, m := range a.Methods {
+		if bytes.Equal(m.Selector[:], data[:4]) {
+			return m, nil
+		}
+	}
+	return Method{}, fmt.Errorf("no function of selector 0x%x in abi", data[:4])
+}
+
+// Event returns the event called name, or with this signature when
+// overloaded.
+func (a *ABI) Event(name string) (Event, error) {
+	var found []Event
+	for _, e := range a.Events {
+		if e.Signature == name {
+			return e, nil
+		}
+		if e.Name == name {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Event{}, fmt.Errorf("no event %s in abi", name)
+	case 1:
+		return found[0], nil
+	}
+	return Event{}, fmt.Errorf("event %s is overloaded, give its signature", name)
+}
+
+// EventByTopic returns the event logged with the first topic topic.
+func (a *ABI) EventByTopic(topic types.Hash) (Event, error) {
+	for _, e := range a.Events {
+		if !e.Anonymous && e.Topic == topic {
+			return e, nil
+		}
+	}
+	return Event{}, fmt.Errorf("no event of topic %s in abi", topic)
+}
+
+// Error returns the error called name, or with this signature when
+// overloaded.
+func (a *ABI) Error(name string) (Error, error) {
+	var found []Error
+	for _, e := range a.Errors {
+		if e.Signature == name {
+			return e, nil
+		}
+		if e.Name == name {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Error{}, fmt.Errorf("no error %s in abi", name)
+	case 1:
+		return found[0], nil
+	}
+	return Error{}, fmt.Errorf("error %s is overloaded, give its signature", name)
+}
+
+// ErrorBySelector returns the error of a revert payload, which starts
+// with its selector.
+func (a *ABI) ErrorBySelector(data []byte) (Error, error) {
+	if len(data) < 4 {
+		return Error{}, fmt.Errorf("revert data of %d bytes without selector", len(data))
+	}
+	for _, e := range a.Errors {
+		if bytes.Equal(e.Selector[:], data[:4]) {
+			return e, nil
+		}
+	}
+	return Error{}, fmt.Errorf("no error of selector 0x%x in abi", data[:4])
+}
+
+// Pack encodes a call of the function name with args, see Method.Pack.
+func (a *ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+	m, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Pack(args...)
+}
+
+// Unpack decodes the output of the function name, see Method.Unpack.
+func (a *ABI) Unpack(name string, data []byte) ([]interface{}, error) {
+	m, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Unpack(data)
+}
+
+// Pack encodes the call data of the method: its selector followed by its
+// arguments. The call data of a constructor is only its arguments, to
+// append to the contract bytecode.
+func (m Method) Pack(args ...interface{}) ([]byte, error) {
+	enc, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.describe(), err)
+	}
+	if m.Type == CONSTRUCTOR {
+		return enc, nil
+	}
+	return append(m.Selector[:len(m.Selector):len(m.Selector)], enc...), nil
+}
+
+// Unpack decodes the output of the method, see Arguments.Unpack.
+func (m Method) Unpack(data []byte) ([]interface{}, error) {
+	values, err := m.Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s output: %w", m.describe(), err)
+	}
+	return values, nil
+}
+
+// UnpackInto decodes the output of the method into out, see
+// Arguments.UnpackInto.
+func (m Method) UnpackInto(out interface{}, data []byte) error {
+	if err := m.Outputs.UnpackInto(out, data); err != nil {
+		return fmt.Errorf("%s output: %w", m.describe(), err)
+	}
+	return nil
+}
+
+// UnpackInput decodes call data of the method, selector included.
+func (m Method) UnpackInput(data []byte) ([]interface{}, error) {
+	if m.Type != CONSTRUCTOR {
+		if len(data) < 4 || !bytes.Equal(data[:4], m.Selector[:]) {
+			return nil, fmt.Errorf("call data is not a call of %s", m.describe())
+		}
+		data = data[4:]
+	}
+	return m.Inputs.Unpack(data)
+}
+
+func (m Method) describe() string {
+	if m.Type == FUNCTION {
+		return m.Signature
+	}
+	return m.Type
+}
+
+// Pack encodes a revert payload of the error: its selector followed by its
+// arguments.
+func (e Error) Pack(args ...interface{}) ([]byte, error) {
+	enc, err := e.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.Signature, err)
+	}
+	return append(e.Selector[:len(e.Selector):len(e.Selector)], enc...), nil
+}
+
+// Unpack decodes the arguments of a revert payload of the error, selector
+// included.
+func (e Error) Unpack(data []byte) ([]interface{}, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], e.Selector[:]) {
+		return nil, fmt.Errorf("revert data is not a %s error", e.Signature)
+	}
+	values, err := e.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", e.Signature, err)
+	}
+	return values, nil
+}
+
+// keccak256 is the legacy Keccak-256 hash used by Ethereum.
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,161 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+const erc20Json = `[
+	{"type":"constructor","inputs":[{"name":"name","type":"string"},{"name":"symbol","type":"string"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"swap","inputs":[{"name":"orders","type":"tuple[]","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[2]"}]}],"outputs":[],"payable":true},
+	{"type":"function","name":"name","inputs":[],"outputs":[{"name":"","type":"string"}],"constant":true},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}],"anonymous":false},
+	{"type":"error","name":"ERC20InsufficientBalance","inputs":[{"name":"sender","type":"address"},{"name":"balance","type":"uint256"},{"name":"needed","type":"uint256"}]},
+	{"type":"receive","stateMutability":"payable"}
+]`
+
+func TestParseJSON(t *testing.T) {
+	a, err := ParseJSON([]byte(erc20Json))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	selectors := map[string]string{
+		"balanceOf": "70a08231",
+		"transfer":  "a9059cbb",
+		"safeTransferFrom(address,address,uint256)":       "42842e0e",
+		"safeTransferFrom(address,address,uint256,bytes)": "b88d4fde",
+		"swap": "d72de5ad",
+	}
+	for name, want := range selectors {
+		m, err := a.Method(name)
+		if err != nil {
+			t.Fatalf("ABI.Method(%s) error = %v", name, err)
+		}
+		if hex.EncodeToString(m.Selector[:]) != want {
+			t.Errorf("ABI.Method(%s) selector = %x, want %s (%s)", name, m.Selector, want, m.Signature)
+		}
+	}
+	if _, err := a.Method("safeTransferFrom"); err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Errorf("ABI.Method(safeTransferFrom) error = %v, want overloaded", err)
+	}
+	if _, err := a.Method("approve"); err == nil {
+		t.Errorf("ABI.Method(approve) error = nil")
+	}
+
+	swap, _ := a.Method("swap")
+	if swap.Signature != "swap((address,uint256[2])[])" || swap.StateMutability != "payable" {
+		t.Errorf("swap = %s %s", swap.Signature, swap.StateMutability)
+	}
+	if name, _ := a.Method("name"); name.StateMutability != "view" {
+		t.Errorf("name mutability = %s, want view", name.StateMutability)
+	}
+	if a.Constructor == nil || len(a.Constructor.Inputs) != 2 || a.Receive == nil || a.Fallback != nil {
+		t.Errorf("ParseJSON() constructor = %v, receive = %v, fallback = %v", a.Constructor, a.Receive, a.Fallback)
+	}
+
+	transfer, err := a.Event("Transfer")
+	if err != nil {
+		t.Fatalf("ABI.Event() error = %v", err)
+	}
+	if transfer.Topic != types.MustParseHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef") || !transfer.Inputs[0].Indexed || transfer.Inputs[2].Indexed {
+		t.Errorf("ABI.Event() = %+v", transfer)
+	}
+	if e, err := a.EventByTopic(transfer.Topic); err != nil || e.Name != "Transfer" {
+		t.Errorf("ABI.EventByTopic() = %v, %v", e, err)
+	}
+	if e, err := a.ErrorBySelector([]byte{0xe4, 0x50, 0xd3, 0x8c, 0}); err != nil || e.Name != "ERC20InsufficientBalance" {
+		t.Errorf("ABI.ErrorBySelector() = %v, %v", e, err)
+	}
+
+	// hardhat and foundry artifacts
+	artifact, err := ParseJSON([]byte(`{"contractName":"Token","abi":` + erc20Json + `}`))
+	if err != nil || len(artifact.Methods) != len(a.Methods) {
+		t.Errorf("ParseJSON(artifact) = %v, %v", artifact, err)
+	}
+
+	for _, invalid := range []string{`{`, `[{"type":"function","name":"f","inputs":[{"type":"uint7"}]}]`, `[{"type":"modifier"}]`, `[{"type":"fallback"},{"type":"fallback"}]`} {
+		if _, err := ParseJSON([]byte(invalid)); err == nil {
+			t.Errorf("ParseJSON(%s) error = nil", invalid)
+		}
+	}
+}
+
+func TestMethod_Pack(t *testing.T) {
+	a, err := ParseJSON([]byte(erc20Json))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	to := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	data, err := a.Pack("transfer", to, big.NewInt(1e18))
+	if err != nil {
+		t.Fatalf("ABI.Pack() error = %v", err)
+	}
+	want := "a9059cbb0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed0000000000000000000000000000000000000000000000000de0b6b3a7640000"
+	if hex.EncodeToString(data) != want {
+		t.Errorf("ABI.Pack() = %x, want %s", data, want)
+	}
+	transfer, _ := a.Method("transfer")
+	inputs, err := transfer.UnpackInput(data)
+	if err != nil || inputs[0] != to || inputs[1].(*big.Int).Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("Method.UnpackInput() = %v, %v", inputs, err)
+	}
+	if m, err := a.MethodBySelector(data); err != nil || m.Name != "transfer" {
+		t.Errorf("ABI.MethodBySelector() = %v, %v", m, err)
+	}
+	if _, err := a.Pack("transfer", to); err == nil || !strings.Contains(err.Error(), "transfer(address,uint256)") {
+		t.Errorf("ABI.Pack() missing argument error = %v", err)
+	}
+
+	ctor, err := a.Constructor.Pack("Token", "TKN")
+	if err != nil || len(ctor)%32 != 0 {
+		t.Errorf("constructor Pack() = %x, %v, want no selector", ctor, err)
+	}
+
+	out := words("0000000000000000000000000000000000000000000000000de0b6b3a7640000")
+	balance, err := a.Unpack("balanceOf", out)
+	if err != nil || balance[0].(*big.Int).Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("ABI.Unpack() = %v, %v", balance, err)
+	}
+	balanceOf, _ := a.Method("balanceOf")
+	var q *types.Quantity
+	if err := balanceOf.UnpackInto(&q, out); err != nil || q.Hex() != "0xde0b6b3a7640000" {
+		t.Errorf("Method.UnpackInto() = %v, %v", q, err)
+	}
+	if _, err := balanceOf.Unpack(out[:31]); err == nil {
+		t.Errorf("Method.Unpack() of truncated output error = nil")
+	}
+}
+
+func TestError_Unpack(t *testing.T) {
+	a, err := ParseJSON([]byte(erc20Json))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	e, err := a.Error("ERC20InsufficientBalance")
+	if err != nil {
+		t.Fatalf("ABI.Error() error = %v", err)
+	}
+	sender := types.MustParseAddress("0x5555763613a12D8F3e73be831DFf8598089d3dCa")
+	payload, err := e.Pack(sender, 100, 250)
+	if err != nil {
+		t.Fatalf("Error.Pack() error = %v", err)
+	}
+	if got := hex.EncodeToString(payload[:4]); got != "e450d38c" {
+		t.Errorf("Error.Pack() selector = %s, want e450d38c", got)
+	}
+	args, err := e.Unpack(payload)
+	if err != nil || args[0] != sender || args[2].(*big.Int).Int64() != 250 {
+		t.Errorf("Error.Unpack() = %v, %v", args, err)
+	}
+	if _, err := e.Unpack(payload[4:]); err == nil {
+		t.Errorf("Error.Unpack() without selector error = nil")
+	}
+}
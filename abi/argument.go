@@ -0,0 +1,105 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Argument is an input or output of a function, error or event.
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool // event argument stored in a topic
+}
+
+// Arguments are the inputs or outputs of a function, error or event,
+// encoded as a tuple.
+type Arguments []Argument
+
+// Types returns the types of the arguments.
+func (args Arguments) Types() []Type {
+	ts := make([]Type, len(args))
+	for i, a := range args {
+		ts[i] = a.Type
+	}
+	return ts
+}
+
+// String returns the canonical types of the arguments, as in signatures.
+func (args Arguments) String() string {
+	ts := make([]string, len(args))
+	for i, a := range args {
+		ts[i] = a.Type.String()
+	}
+	return strings.Join(ts, ",")
+}
+
+// Pack encodes values, one per argument. Integers are given as Go
+// integers, *big.Int or *types.Quantity, addresses as types.Address or hex
+// strings, bytes as byte slices or arrays, arrays as slices or arrays and
+// tuples as structs or slices of their fields.
+func (args Arguments) Pack(values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("%d values for %d arguments", len(values), len(args))
+	}
+	enc, err := encodeTuple(args.Types(), values)
+	if err, ok := err.(*tupleError); ok {
+		return nil, fmt.Errorf("argument %s: %w", argumentName(args[err.index], err.index), err.err)
+	}
+	return enc, err
+}
+
+// Unpack decodes data into one value per argument: *big.Int for integers,
+// types.Address, bool, string, []byte for bytes and bytesN, and
+// []interface{} for arrays and tuples.
+func (args Arguments) Unpack(data []byte) ([]interface{}, error) {
+	return decodeTuple(args.Types(), data)
+}
+
+// UnpackInto decodes data into out, a pointer. A single argument is
+// stored in out as Copy does, many in the fields of the struct out points
+// to, matched by argument name as for tuples.
+func (args Arguments) UnpackInto(out interface{}, data []byte) error {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return err
+	}
+	return args.Copy(out, values)
+}
+
+// Copy stores values unpacked from args in out, as UnpackInto does.
+func (args Arguments) Copy(out interface{}, values []interface{}) error {
+	if len(values) != len(args) {
+		return fmt.Errorf("%d values for %d arguments", len(values), len(args))
+	}
+	if len(args) == 1 {
+		rv := reflect.ValueOf(out)
+		// a struct is the tuple of the arguments unless the argument itself
+		// is one
+		if args[0].Type.Kind == TUPLE || !pointsToStruct(rv) {
+			return Copy(out, args[0].Type, values[0])
+		}
+	}
+	tuple := Type{Kind: TUPLE, Fields: args.Types()}
+	for _, a := range args {
+		tuple.FieldNames = append(tuple.FieldNames, a.Name)
+	}
+	return Copy(out, tuple, values)
+}
+
+func argumentName(a Argument, i int) string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return fmt.Sprint(i)
+}
+
+// pointsToStruct tells if rv points to a struct other than a big integer.
+func pointsToStruct(rv reflect.Value) bool {
+	if rv.Kind() != reflect.Pointer {
+		return false
+	}
+	t := rv.Type().Elem()
+	return t.Kind() == reflect.Struct && t != bigType && t != quantityType
+}
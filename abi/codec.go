@@ -0,0 +1,289 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+var (
+	two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+	// offsets and lengths beyond this cannot be in a real payload
+	maxLength = big.NewInt(int64(^uint32(0)))
+)
+
+// encodeTuple encodes values laid out as a tuple of ts: the static values
+// and the offsets of the dynamic ones in the head, then the dynamic
+// values.
+func encodeTuple(ts []Type, values []interface{}) ([]byte, error) {
+	if len(values) != len(ts) {
+		return nil, fmt.Errorf("%d values for %d abi types", len(values), len(ts))
+	}
+	headSize := 0
+	for _, t := range ts {
+		headSize += t.headSize()
+	}
+	head := make([]byte, 0, headSize)
+	var tail []byte
+	for i, t := range ts {
+		enc, err := encodeValue(t, values[i])
+		if err != nil {
+			return nil, &tupleError{i, err}
+		}
+		if !t.Dynamic() {
+			head = append(head, enc...)
+			continue
+		}
+		head = append(head, word(big.NewInt(int64(headSize+len(tail))))...)
+		tail = append(tail, enc...)
+	}
+	return append(head, tail...), nil
+}
+
+func encodeValue(t Type, v interface{}) ([]byte, error) {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("nil value for abi type %s", t)
+	}
+	switch t.Kind {
+	case SLICE, ARRAY:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("%T for abi type %s", v, t)
+		}
+		if t.Kind == ARRAY && rv.Len() != t.Size {
+			return nil, fmt.Errorf("%d elements for abi type %s", rv.Len(), t)
+		}
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i] = rv.Index(i).Interface()
+		}
+		enc, err := encodeTuple(repeatType(*t.Elem, len(elems)), elems)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == SLICE {
+			enc = append(word(big.NewInt(int64(len(elems)))), enc...)
+		}
+		return enc, nil
+	case TUPLE:
+		fields, err := tupleValues(t, rv)
+		if err != nil {
+			return nil, err
+		}
+		return encodeTuple(t.Fields, fields)
+	case BYTES, STRING:
+		var b []byte
+		if t.Kind == STRING && rv.Kind() == reflect.String {
+			b = []byte(rv.String())
+		} else if bs, ok := asBytes(rv); ok && t.Kind == BYTES {
+			b = bs
+		} else {
+			return nil, fmt.Errorf("%T for abi type %s", v, t)
+		}
+		padded := make([]byte, (len(b)+31)/32*32)
+		copy(padded, b)
+		return append(word(big.NewInt(int64(len(b)))), padded...), nil
+	case FIXED_BYTES:
+		b, ok := asBytes(rv)
+		if !ok || len(b) != t.Size {
+			return nil, fmt.Errorf("%T for abi type %s, want %d bytes", v, t, t.Size)
+		}
+		padded := make([]byte, 32)
+		copy(padded, b)
+		return padded, nil
+	case ADDRESS:
+		switch a := rv.Interface().(type) {
+		case types.Address:
+			return word(new(big.Int).SetBytes(a.Bytes())), nil
+		case string:
+			address, err := types.ParseAddress(a)
+			if err != nil {
+				return nil, err
+			}
+			return word(new(big.Int).SetBytes(address.Bytes())), nil
+		}
+		if b, ok := asBytes(rv); ok && len(b) == types.ADDRESS_LENGTH {
+			return word(new(big.Int).SetBytes(b)), nil
+		}
+		return nil, fmt.Errorf("%T for abi type %s", v, t)
+	case BOOL:
+		if rv.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("%T for abi type %s", v, t)
+		}
+		if rv.Bool() {
+			return word(big.NewInt(1)), nil
+		}
+		return word(new(big.Int)), nil
+	case UINT, INT:
+		x, ok := asBig(rv)
+		if !ok {
+			return nil, fmt.Errorf("%T for abi type %s", v, t)
+		}
+		if !fits(t, x) {
+			return nil, fmt.Errorf("%s out of the range of abi type %s", x, t)
+		}
+		if x.Sign() < 0 {
+			// two's complement over 256 bits
+			x = new(big.Int).Add(x, two256)
+		}
+		return word(x), nil
+	}
+	return nil, fmt.Errorf("unsupported abi type %s", t)
+}
+
+// tupleValues returns the values of the fields of a tuple given as a
+// slice, or as a struct whose fields are matched by name when the tuple
+// names them, else by position.
+func tupleValues(t Type, rv reflect.Value) ([]interface{}, error) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() != len(t.Fields) {
+			return nil, fmt.Errorf("%d values for abi type %s", rv.Len(), t)
+		}
+		values := make([]interface{}, rv.Len())
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+		return values, nil
+	case reflect.Struct:
+		fields, err := structFields(t, rv.Type())
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, len(fields))
+		for i, f := range fields {
+			values[i] = rv.FieldByIndex(f).Interface()
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("%s for abi type %s", rv.Type(), t)
+}
+
+// tupleError tells which value of a tuple could not be encoded.
+type tupleError struct {
+	index int
+	err   error
+}
+
+func (e *tupleError) Error() string {
+	return fmt.Sprintf("value %d: %v", e.index, e.err)
+}
+
+func (e *tupleError) Unwrap() error {
+	return e.err
+}
+
+// word encodes a non negative integer of at most 256 bits.
+func word(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, 32))
+}
+
+// fits tells if x is in the range of the integer type t.
+func fits(t Type, x *big.Int) bool {
+	if t.Kind == UINT {
+		return x.Sign() >= 0 && x.BitLen() <= t.Size
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	return x.Cmp(limit) < 0 && x.Cmp(new(big.Int).Neg(limit)) >= 0
+}
+
+// decodeTuple decodes values laid out as a tuple of ts in data.
+func decodeTuple(ts []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(ts))
+	head := 0
+	for i, t := range ts {
+		if head+t.headSize() > len(data) {
+			return nil, fmt.Errorf("abi data too short: %d bytes", len(data))
+		}
+		at := data[head:]
+		if t.Dynamic() {
+			offset, err := readLength(data[head:], "offset")
+			if err != nil {
+				return nil, err
+			}
+			if offset > len(data) {
+				return nil, fmt.Errorf("abi offset %d out of %d bytes", offset, len(data))
+			}
+			at = data[offset:]
+		}
+		v, err := decodeValue(t, at)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+		head += t.headSize()
+	}
+	return values, nil
+}
+
+func decodeValue(t Type, data []byte) (interface{}, error) {
+	switch t.Kind {
+	case SLICE:
+		length, err := readLength(data, "array length")
+		if err != nil {
+			return nil, err
+		}
+		// each element takes at least a word
+		if length > len(data)/32 {
+			return nil, fmt.Errorf("abi array length %d out of %d bytes", length, len(data))
+		}
+		return decodeTuple(repeatType(*t.Elem, length), data[32:])
+	case ARRAY:
+		return decodeTuple(repeatType(*t.Elem, t.Size), data)
+	case TUPLE:
+		return decodeTuple(t.Fields, data)
+	case BYTES, STRING:
+		length, err := readLength(data, "bytes length")
+		if err != nil {
+			return nil, err
+		}
+		if 32+length > len(data) {
+			return nil, fmt.Errorf("abi bytes length %d out of %d bytes", length, len(data))
+		}
+		b := append([]byte(nil), data[32:32+length]...)
+		if t.Kind == STRING {
+			return string(b), nil
+		}
+		return b, nil
+	}
+
+	x, err := readWord(data)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Kind {
+	case ADDRESS:
+		return types.BytesToAddress(data[12:32]), nil
+	case BOOL:
+		return x.Sign() != 0, nil
+	case FIXED_BYTES:
+		return append([]byte(nil), data[:t.Size]...), nil
+	case INT:
+		// two's complement over 256 bits
+		if data[0]&0x80 != 0 {
+			x.Sub(x, two256)
+		}
+	}
+	return x, nil
+}
+
+func readWord(data []byte) (*big.Int, error) {
+	if len(data) < 32 {
+		return nil, fmt.Errorf("abi data too short: %d bytes", len(data))
+	}
+	return new(big.Int).SetBytes(data[:32]), nil
+}
+
+// readLength reads an offset or a length word.
+func readLength(data []byte, what string) (int, error) {
+	x, err := readWord(data)
+	if err != nil {
+		return 0, err
+	}
+	if x.Cmp(maxLength) > 0 {
+		return 0, fmt.Errorf("abi %s %s too large", what, x)
+	}
+	return int(x.Int64()), nil
+}
@@ -0,0 +1,277 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// words joins hex encoded 32 bytes words.
+func words(ws ...string) []byte {
+	b, err := hex.DecodeString(strings.Join(ws, ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func mustArguments(t *testing.T, ts ...string) Arguments {
+	t.Helper()
+	args := make(Arguments, len(ts))
+	for i, s := range ts {
+		typ, err := ParseType(s)
+		if err != nil {
+			t.Fatalf("ParseType(%s) error = %v", s, err)
+		}
+		args[i] = Argument{Type: typ}
+	}
+	return args
+}
+
+// examples of the solidity ABI specification
+func TestArguments_Pack_specification(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		types     []string
+		values    []interface{}
+		selector  string
+		want      []byte
+	}{
+		{
+			name:      "static",
+			signature: "baz(uint32,bool)",
+			types:     []string{"uint32", "bool"},
+			values:    []interface{}{uint32(69), true},
+			selector:  "cdcd77c0",
+			want: words(
+				"0000000000000000000000000000000000000000000000000000000000000045",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			),
+		},
+		{
+			name:      "fixed bytes array",
+			signature: "bar(bytes3[2])",
+			types:     []string{"bytes3[2]"},
+			values:    []interface{}{[][]byte{[]byte("abc"), []byte("def")}},
+			selector:  "fce353f6",
+			want: words(
+				"6162630000000000000000000000000000000000000000000000000000000000",
+				"6465660000000000000000000000000000000000000000000000000000000000",
+			),
+		},
+		{
+			name:      "dynamic",
+			signature: "sam(bytes,bool,uint256[])",
+			types:     []string{"bytes", "bool", "uint256[]"},
+			values:    []interface{}{[]byte("dave"), true, []int{1, 2, 3}},
+			selector:  "a5643bf2",
+			want: words(
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000004",
+				"6461766500000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+			),
+		},
+		{
+			name:      "mixed",
+			signature: "f(uint256,uint32[],bytes10,bytes)",
+			types:     []string{"uint256", "uint32[]", "bytes10", "bytes"},
+			values:    []interface{}{big.NewInt(0x123), []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			selector:  "8be65246",
+			want: words(
+				"0000000000000000000000000000000000000000000000000000000000000123",
+				"0000000000000000000000000000000000000000000000000000000000000080",
+				"3132333435363738393000000000000000000000000000000000000000000000",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000456",
+				"0000000000000000000000000000000000000000000000000000000000000789",
+				"000000000000000000000000000000000000000000000000000000000000000d",
+				"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+			),
+		},
+		{
+			name:      "nested dynamic",
+			signature: "g(uint256[][],string[])",
+			types:     []string{"uint256[][]", "string[]"},
+			values:    []interface{}{[][]int{{1, 2}, {3}}, []string{"one", "two", "three"}},
+			selector:  "2289b18c",
+			want: words(
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"0000000000000000000000000000000000000000000000000000000000000140",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000002",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"00000000000000000000000000000000000000000000000000000000000000e0",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"6f6e650000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"74776f0000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000005",
+				"7468726565000000000000000000000000000000000000000000000000000000",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := mustArguments(t, tt.types...)
+			if got := args.String(); tt.signature != strings.SplitN(tt.signature, "(", 2)[0]+"("+got+")" {
+				t.Errorf("Arguments.String() = %s, signature %s", got, tt.signature)
+			}
+			if sel := Selector(tt.signature); hex.EncodeToString(sel[:]) != tt.selector {
+				t.Errorf("Selector(%s) = %x, want %s", tt.signature, sel, tt.selector)
+			}
+			got, err := args.Pack(tt.values...)
+			if err != nil {
+				t.Fatalf("Arguments.Pack() error = %v", err)
+			}
+			if hex.EncodeToString(got) != hex.EncodeToString(tt.want) {
+				t.Errorf("Arguments.Pack() = %x, want %x", got, tt.want)
+			}
+			// decoding gives the values back, as decoded types
+			values, err := args.Unpack(tt.want)
+			if err != nil {
+				t.Fatalf("Arguments.Unpack() error = %v", err)
+			}
+			again, err := args.Pack(values...)
+			if err != nil || hex.EncodeToString(again) != hex.EncodeToString(tt.want) {
+				t.Errorf("Arguments.Pack(Unpack()) = %x, %v, want %x", again, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestArguments_Unpack(t *testing.T) {
+	data := words(
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"deadbeef00000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"6162000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"6364650000000000000000000000000000000000000000000000000000000000",
+	)
+	got, err := mustArguments(t, "int8", "string[]", "bytes4").Unpack(data)
+	if err != nil {
+		t.Fatalf("Arguments.Unpack() error = %v", err)
+	}
+	want := []interface{}{big.NewInt(-1), []interface{}{"ab", "cde"}, []byte{0xde, 0xad, 0xbe, 0xef}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Arguments.Unpack() = %v, want %v", got, want)
+	}
+
+	invalid := []struct {
+		name  string
+		types []string
+		data  []byte
+	}{
+		{"short", []string{"uint256", "uint256"}, words("0000000000000000000000000000000000000000000000000000000000000001")},
+		{"offset out of data", []string{"bytes"}, words("0000000000000000000000000000000000000000000000000000000000000040")},
+		{"huge offset", []string{"string"}, words("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")},
+		{
+			name:  "huge array length",
+			types: []string{"uint256[]"},
+			data: words(
+				"0000000000000000000000000000000000000000000000000000000000000020",
+				"00000000000000000000000000000000000000000000000000000000ffffffff",
+			),
+		},
+		{
+			name:  "bytes past the end",
+			types: []string{"bytes"},
+			data: words(
+				"0000000000000000000000000000000000000000000000000000000000000020",
+				"0000000000000000000000000000000000000000000000000000000000000021",
+				"6162000000000000000000000000000000000000000000000000000000000000",
+			),
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := mustArguments(t, tt.types...).Unpack(tt.data); err == nil {
+				t.Errorf("Arguments.Unpack() error = nil")
+			}
+		})
+	}
+}
+
+func TestArguments_Pack(t *testing.T) {
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	type order struct {
+		Maker  types.Address
+		Amount *big.Int
+		Tags   []string
+	}
+	tuple := Argument{Name: "order", Type: MustParseType("(address maker, uint256 amount, string[] tags)")}
+	wantTuple, err := Arguments{tuple}.Pack([]interface{}{address, big.NewInt(7), []string{"a"}})
+	if err != nil {
+		t.Fatalf("Arguments.Pack() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		args    Arguments
+		values  []interface{}
+		want    []byte
+		wantErr string
+	}{
+		{name: "address string", args: mustArguments(t, "address"), values: []interface{}{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+			want: words("0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed")},
+		{name: "address pointer", args: mustArguments(t, "address"), values: []interface{}{&address},
+			want: words("0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed")},
+		{name: "negative int", args: mustArguments(t, "int16"), values: []interface{}{-2},
+			want: words("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe")},
+		{name: "quantity", args: mustArguments(t, "uint64"), values: []interface{}{types.Uint64Quantity(16)},
+			want: words("0000000000000000000000000000000000000000000000000000000000000010")},
+		{name: "hash as bytes32", args: mustArguments(t, "bytes32"), values: []interface{}{types.MustParseHash("0x0000000000000000000000000000000000000000000000000000000000000001")},
+			want: words("0000000000000000000000000000000000000000000000000000000000000001")},
+		{name: "tuple struct", args: Arguments{tuple}, values: []interface{}{order{address, big.NewInt(7), []string{"a"}}}, want: wantTuple},
+		{name: "uint overflow", args: mustArguments(t, "uint8"), values: []interface{}{256}, wantErr: "argument 0: 256 out of the range of abi type uint8"},
+		{name: "negative uint", args: mustArguments(t, "uint256"), values: []interface{}{big.NewInt(-1)}, wantErr: "out of the range"},
+		{name: "int overflow", args: mustArguments(t, "int8"), values: []interface{}{128}, wantErr: "out of the range"},
+		{name: "short bytes4", args: mustArguments(t, "bytes4"), values: []interface{}{[]byte{1}}, wantErr: "want 4 bytes"},
+		{name: "array length", args: mustArguments(t, "uint8[2]"), values: []interface{}{[]int{1}}, wantErr: "1 elements"},
+		{name: "string for bytes", args: mustArguments(t, "bytes"), values: []interface{}{"0x01"}, wantErr: "string for abi type bytes"},
+		{name: "nil", args: mustArguments(t, "uint256"), values: []interface{}{nil}, wantErr: "nil value"},
+		{name: "count", args: mustArguments(t, "uint256"), values: []interface{}{1, 2}, wantErr: "2 values for 1 arguments"},
+		{name: "named argument", args: Arguments{{Name: "amount", Type: MustParseType("uint8")}}, values: []interface{}{-1}, wantErr: "argument amount:"},
+		{name: "tuple struct field", args: Arguments{tuple}, values: []interface{}{struct{ Maker types.Address }{}}, wantErr: "no field for amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.Pack(tt.values...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Arguments.Pack() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Arguments.Pack() error = %v", err)
+			}
+			if hex.EncodeToString(got) != hex.EncodeToString(tt.want) {
+				t.Errorf("Arguments.Pack() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
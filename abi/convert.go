@@ -0,0 +1,201 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+var (
+	bigType      = reflect.TypeOf(big.Int{})
+	quantityType = reflect.TypeOf(types.Quantity{})
+	addressType  = reflect.TypeOf(types.Address{})
+)
+
+// indirect follows pointers down to the value, keeping big integers
+// addressable.
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.IsValid() && (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// asBytes returns a byte slice or array as a slice.
+func asBytes(rv reflect.Value) ([]byte, bool) {
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	b := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(b), rv)
+	return b, true
+}
+
+// asBig returns an integer, *big.Int or *types.Quantity as a *big.Int.
+func asBig(rv reflect.Value) (*big.Int, bool) {
+	switch {
+	case rv.Type() == bigType || rv.Type() == quantityType:
+		x := rv.Convert(bigType).Interface().(big.Int)
+		return new(big.Int).Set(&x), true
+	case rv.CanInt():
+		return big.NewInt(rv.Int()), true
+	case rv.CanUint():
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+// structFields returns the index of the field of struct st holding each
+// field of the tuple t. Fields are matched by name, their abi tag or their
+// name ignoring case and leading underscores, when the tuple names all of
+// them and else by position. Fields tagged abi:"-" are skipped.
+func structFields(t Type, st reflect.Type) ([][]int, error) {
+	named := len(t.FieldNames) == len(t.Fields)
+	for _, name := range t.FieldNames {
+		named = named && name != ""
+	}
+	var exported []reflect.StructField
+	for _, f := range reflect.VisibleFields(st) {
+		if f.IsExported() && !f.Anonymous && f.Tag.Get("abi") != "-" {
+			exported = append(exported, f)
+		}
+	}
+	if !named {
+		if len(exported) != len(t.Fields) {
+			return nil, fmt.Errorf("%s has %d fields for abi type %s", st, len(exported), t)
+		}
+		index := make([][]int, len(exported))
+		for i, f := range exported {
+			index[i] = f.Index
+		}
+		return index, nil
+	}
+	index := make([][]int, len(t.Fields))
+	for i, name := range t.FieldNames {
+		for _, f := range exported {
+			if f.Tag.Get("abi") == name || strings.EqualFold(f.Name, strings.TrimLeft(name, "_")) {
+				index[i] = f.Index
+				break
+			}
+		}
+		if index[i] == nil {
+			return nil, fmt.Errorf("%s has no field for %s of abi type %s", st, name, t)
+		}
+	}
+	return index, nil
+}
+
+// Copy sets dst, a pointer, to the decoded value v of type t. Integers go
+// to *big.Int, *types.Quantity or Go integers they fit in, bytesN to byte
+// slices or arrays of N bytes such as types.Hash, arrays to slices or
+// arrays and tuples to structs, see structFields. An interface{} receives
+// v as is.
+func Copy(dst interface{}, t Type, v interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("abi copy to %T, want a non nil pointer", dst)
+	}
+	return assign(rv.Elem(), t, v)
+}
+
+func assign(dst reflect.Value, t Type, v interface{}) error {
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer && dst.Type().Elem() != bigType && dst.Type().Elem() != quantityType {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(dst.Elem(), t, v)
+	}
+	mismatch := fmt.Errorf("abi type %s cannot be stored in %s", t, dst.Type())
+
+	switch t.Kind {
+	case UINT, INT:
+		x := v.(*big.Int)
+		switch {
+		case dst.Type() == reflect.PointerTo(bigType):
+			dst.Set(reflect.ValueOf(new(big.Int).Set(x)))
+		case dst.Type() == reflect.PointerTo(quantityType):
+			if x.Sign() < 0 {
+				return fmt.Errorf("negative %s stored in a quantity", x)
+			}
+			dst.Set(reflect.ValueOf(types.NewQuantity(new(big.Int).Set(x))))
+		case dst.Type() == bigType:
+			dst.Set(reflect.ValueOf(*new(big.Int).Set(x)))
+		case dst.CanInt():
+			if !x.IsInt64() || dst.OverflowInt(x.Int64()) {
+				return fmt.Errorf("%s overflows %s", x, dst.Type())
+			}
+			dst.SetInt(x.Int64())
+		case dst.CanUint():
+			if !x.IsUint64() || dst.OverflowUint(x.Uint64()) {
+				return fmt.Errorf("%s overflows %s", x, dst.Type())
+			}
+			dst.SetUint(x.Uint64())
+		default:
+			return mismatch
+		}
+	case ADDRESS:
+		if dst.Type() != addressType {
+			return mismatch
+		}
+		dst.Set(reflect.ValueOf(v))
+	case BOOL, STRING:
+		if dst.Kind() != reflect.ValueOf(v).Kind() {
+			return mismatch
+		}
+		dst.Set(reflect.ValueOf(v).Convert(dst.Type()))
+	case BYTES, FIXED_BYTES:
+		b := v.([]byte)
+		switch {
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes(append([]byte(nil), b...))
+		case dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == len(b):
+			reflect.Copy(dst, reflect.ValueOf(b))
+		default:
+			return mismatch
+		}
+	case SLICE, ARRAY:
+		elems := v.([]interface{})
+		switch dst.Kind() {
+		case reflect.Slice:
+			dst.Set(reflect.MakeSlice(dst.Type(), len(elems), len(elems)))
+		case reflect.Array:
+			if dst.Len() != len(elems) {
+				return mismatch
+			}
+		default:
+			return mismatch
+		}
+		for i, elem := range elems {
+			if err := assign(dst.Index(i), *t.Elem, elem); err != nil {
+				return err
+			}
+		}
+	case TUPLE:
+		fields := v.([]interface{})
+		if dst.Kind() != reflect.Struct {
+			return mismatch
+		}
+		index, err := structFields(t, dst.Type())
+		if err != nil {
+			return err
+		}
+		for i, f := range index {
+			if err := assign(dst.FieldByIndex(f), t.Fields[i], fields[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		return mismatch
+	}
+	return nil
+}
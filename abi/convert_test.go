@@ -0,0 +1,143 @@
+package abi
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+func TestCopy(t *testing.T) {
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	hash := types.MustParseHash("0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3")
+
+	var (
+		asBig      *big.Int
+		asQuantity *types.Quantity
+		asUint8    uint8
+		asInt64    int64
+		asHash     types.Hash
+		asBytes    []byte
+		asData     types.Data
+		asAddress  *types.Address
+		asArray    [2]uint64
+		asAny      interface{}
+		asSkipped  struct {
+			A    uint8
+			Note string `abi:"-"`
+			B    bool
+		}
+		asStruct struct {
+			Owner  types.Address `abi:"_owner"`
+			Amount uint64
+			Tags   []string
+		}
+	)
+	tests := []struct {
+		name  string
+		dst   interface{}
+		typ   string
+		value interface{}
+		want  interface{}
+	}{
+		{"big", &asBig, "uint256", big.NewInt(5), big.NewInt(5)},
+		{"quantity", &asQuantity, "uint256", big.NewInt(5), types.Uint64Quantity(5)},
+		{"uint8", &asUint8, "uint8", big.NewInt(255), uint8(255)},
+		{"int64", &asInt64, "int64", big.NewInt(-3), int64(-3)},
+		{"hash", &asHash, "bytes32", hash.Bytes(), hash},
+		{"bytes", &asBytes, "bytes", []byte{1, 2}, []byte{1, 2}},
+		{"data", &asData, "bytes", []byte{1, 2}, types.Data{1, 2}},
+		{"address pointer", &asAddress, "address", address, &address},
+		{"array", &asArray, "uint64[2]", []interface{}{big.NewInt(1), big.NewInt(2)}, [2]uint64{1, 2}},
+		{"interface", &asAny, "string", "hi", "hi"},
+		{"skipped field", &asSkipped, "(uint8,bool)", []interface{}{big.NewInt(1), true}, struct {
+			A    uint8
+			Note string `abi:"-"`
+			B    bool
+		}{1, "", true}},
+		{
+			name:  "struct",
+			dst:   &asStruct,
+			typ:   "(address _owner, uint64 amount, string[] tags)",
+			value: []interface{}{address, big.NewInt(9), []interface{}{"a", "b"}},
+			want: struct {
+				Owner  types.Address `abi:"_owner"`
+				Amount uint64
+				Tags   []string
+			}{address, 9, []string{"a", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Copy(tt.dst, MustParseType(tt.typ), tt.value); err != nil {
+				t.Fatalf("Copy() error = %v", err)
+			}
+			got := reflect.ValueOf(tt.dst).Elem().Interface()
+			if g, ok := got.(*big.Int); ok {
+				got = g.String()
+				tt.want = tt.want.(*big.Int).String()
+			}
+			if g, ok := got.(*types.Quantity); ok {
+				got = g.Hex()
+				tt.want = tt.want.(*types.Quantity).Hex()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Copy() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name    string
+		dst     interface{}
+		typ     string
+		value   interface{}
+		wantErr string
+	}{
+		{"not a pointer", asUint8, "uint8", big.NewInt(1), "want a non nil pointer"},
+		{"overflow", &asUint8, "uint16", big.NewInt(256), "overflows uint8"},
+		{"negative quantity", &asQuantity, "int8", big.NewInt(-1), "negative"},
+		{"wrong length", &asHash, "bytes4", []byte{1, 2, 3, 4}, "cannot be stored"},
+		{"wrong kind", &asBytes, "string", "hi", "cannot be stored"},
+		{"missing field", &asStruct, "(address owner, uint64 amount, string[] tags, bool paused)", []interface{}{address, big.NewInt(1), []interface{}{}, true}, "no field for paused"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Copy(tt.dst, MustParseType(tt.typ), tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Copy() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArguments_UnpackInto(t *testing.T) {
+	args := Arguments{
+		{Name: "reserve0", Type: MustParseType("uint112")},
+		{Name: "reserve1", Type: MustParseType("uint112")},
+		{Name: "blockTimestampLast", Type: MustParseType("uint32")},
+	}
+	data, err := args.Pack(1000, 2000, 1700000000)
+	if err != nil {
+		t.Fatalf("Arguments.Pack() error = %v", err)
+	}
+	var reserves struct {
+		Reserve0           *big.Int
+		Reserve1           *big.Int
+		BlockTimestampLast uint32
+	}
+	if err := args.UnpackInto(&reserves, data); err != nil {
+		t.Fatalf("Arguments.UnpackInto() error = %v", err)
+	}
+	if reserves.Reserve0.Int64() != 1000 || reserves.Reserve1.Int64() != 2000 || reserves.BlockTimestampLast != 1700000000 {
+		t.Errorf("Arguments.UnpackInto() = %+v", reserves)
+	}
+
+	// a single output goes to out itself, be it a big integer
+	var supply *big.Int
+	if err := args[:1].UnpackInto(&supply, data[:32]); err != nil || supply.Int64() != 1000 {
+		t.Errorf("Arguments.UnpackInto() = %v, %v, want 1000", supply, err)
+	}
+}
@@ -0,0 +1,142 @@
+package abi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// Topics returns the topics of an eth_getLogs filter matching the event:
+// its topic, unless anonymous, then one per indexed input, given in order.
+// A nil value, a nil pointer or slice, or a missing value matches any
+// value.
+// Strings and bytes match their keccak256 hash, as logged.
+func (e Event) Topics(indexed ...interface{}) ([]*types.Hash, error) {
+	var topics []*types.Hash
+	if !e.Anonymous {
+		topic := e.Topic
+		topics = append(topics, &topic)
+	}
+	i := 0
+	for _, in := range e.Inputs {
+		if !in.Indexed {
+			continue
+		}
+		if i >= len(indexed) {
+			break
+		}
+		value := indexed[i]
+		i++
+		if rv := indirect(reflect.ValueOf(value)); !rv.IsValid() || rv.Kind() == reflect.Slice && rv.IsNil() {
+			topics = append(topics, nil)
+			continue
+		}
+		topic, err := topicOf(in.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s topic %s: %w", e.Signature, argumentName(in, i-1), err)
+		}
+		topics = append(topics, &topic)
+	}
+	if i < len(indexed) {
+		return nil, fmt.Errorf("%s has %d indexed inputs, %d values given", e.Signature, i, len(indexed))
+	}
+	// trailing topics matching anything are left out
+	for len(topics) > 0 && topics[len(topics)-1] == nil {
+		topics = topics[:len(topics)-1]
+	}
+	return topics, nil
+}
+
+func topicOf(t Type, value interface{}) (types.Hash, error) {
+	switch t.Kind {
+	case BYTES, STRING:
+		enc, err := encodeValue(t, value)
+		if err != nil {
+			return types.Hash{}, err
+		}
+		length, _ := readLength(enc, "bytes length")
+		return types.BytesToHash(keccak256(enc[32 : 32+length])), nil
+	case SLICE, ARRAY, TUPLE:
+		return types.Hash{}, fmt.Errorf("indexed %s values are not supported, give their hash", t)
+	}
+	enc, err := encodeValue(t, value)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.BytesToHash(enc), nil
+}
+
+// Unpack decodes a log of the event into one value per input, as
+// Arguments.Unpack does. Indexed strings, bytes, arrays and tuples are only
+// logged as their hash, a types.Hash.
+func (e Event) Unpack(topics []types.Hash, data []byte) ([]interface{}, error) {
+	_, values, err := e.unpack(topics, data)
+	if err != nil {
+		return nil, err
+	}
+	for i, in := range e.Inputs {
+		if in.Indexed && in.Type.HashedWhenIndexed() {
+			values[i] = types.BytesToHash(values[i].([]byte))
+		}
+	}
+	return values, nil
+}
+
+// UnpackInto decodes a log of the event into out, as
+// Arguments.UnpackInto does. Hashes of indexed strings, bytes, arrays and
+// tuples go to bytes32 fields such as types.Hash.
+func (e Event) UnpackInto(out interface{}, topics []types.Hash, data []byte) error {
+	args, values, err := e.unpack(topics, data)
+	if err != nil {
+		return err
+	}
+	if err := args.Copy(out, values); err != nil {
+		return fmt.Errorf("%s: %w", e.Signature, err)
+	}
+	return nil
+}
+
+// unpack decodes a log, with the arguments it was decoded as: hashed
+// inputs are bytes32.
+func (e Event) unpack(topics []types.Hash, data []byte) (Arguments, []interface{}, error) {
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.Topic {
+			return nil, nil, fmt.Errorf("log is not a %s event", e.Signature)
+		}
+		topics = topics[1:]
+	}
+	args := make(Arguments, len(e.Inputs))
+	var indexed, nonIndexed Arguments
+	for i, in := range e.Inputs {
+		args[i] = in
+		if !in.Indexed {
+			nonIndexed = append(nonIndexed, in)
+			continue
+		}
+		if in.Type.HashedWhenIndexed() {
+			args[i].Type = Type{Kind: FIXED_BYTES, Size: 32}
+		}
+		indexed = append(indexed, args[i])
+	}
+	if len(topics) != len(indexed) {
+		return nil, nil, fmt.Errorf("%s log with %d indexed topics, want %d", e.Signature, len(topics), len(indexed))
+	}
+	fields, err := nonIndexed.Unpack(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s log data: %w", e.Signature, err)
+	}
+	values := make([]interface{}, len(args))
+	for i, in := range args {
+		if !in.Indexed {
+			values[i], fields = fields[0], fields[1:]
+			continue
+		}
+		value, err := decodeValue(in.Type, topics[0].Bytes())
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s topic %s: %w", e.Signature, argumentName(in, i), err)
+		}
+		values[i], topics = value, topics[1:]
+	}
+	return args, values, nil
+}
@@ -0,0 +1,168 @@
+package abi
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+func TestEvent_Topics(t *testing.T) {
+	a := MustParseHuman(
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Registered(string indexed name, int8 indexed level, bytes data)",
+		"event Anon(uint256 indexed id) anonymous",
+		"event Batch(uint256[] indexed ids)",
+	)
+	from := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	transfer, _ := a.Event("Transfer")
+	registered, _ := a.Event("Registered")
+	anon, _ := a.Event("Anon")
+	batch, _ := a.Event("Batch")
+	hash := func(s string) *types.Hash {
+		h := types.MustParseHash(s)
+		return &h
+	}
+	tests := []struct {
+		name    string
+		event   Event
+		indexed []interface{}
+		want    []*types.Hash
+		wantErr string
+	}{
+		{"any", transfer, nil, []*types.Hash{&transfer.Topic}, ""},
+		{"from", transfer, []interface{}{from}, []*types.Hash{&transfer.Topic, hash("0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed")}, ""},
+		{"to", transfer, []interface{}{nil, from}, []*types.Hash{&transfer.Topic, nil, hash("0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed")}, ""},
+		{"trailing any", transfer, []interface{}{nil, nil}, []*types.Hash{&transfer.Topic}, ""},
+		{"nil pointer", transfer, []interface{}{(*types.Address)(nil), &from}, []*types.Hash{&transfer.Topic, nil, hash("0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed")}, ""},
+		{
+			name:    "hashed string and negative int",
+			event:   registered,
+			indexed: []interface{}{"alice", -1},
+			want: []*types.Hash{&registered.Topic,
+				hash("0x9c0257114eb9399a2985f8e75dad7600c5d89fe3824ffa99ec1c3eb8bf3b0501"),
+				hash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")},
+		},
+		{"anonymous", anon, []interface{}{7}, []*types.Hash{hash("0x0000000000000000000000000000000000000000000000000000000000000007")}, ""},
+		{"too many", transfer, []interface{}{nil, nil, 1}, nil, "2 indexed inputs, 3 values"},
+		{"invalid value", transfer, []interface{}{"bob"}, nil, "topic from"},
+		{"array", batch, []interface{}{[]int{1}}, nil, "not supported"},
+		{"nil slice", batch, []interface{}{[]int(nil)}, []*types.Hash{&batch.Topic}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.event.Topics(tt.indexed...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Event.Topics() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Event.Topics() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Event.Topics() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if (got[i] == nil) != (tt.want[i] == nil) || got[i] != nil && *got[i] != *tt.want[i] {
+					t.Errorf("Event.Topics()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEvent_Unpack(t *testing.T) {
+	a := MustParseHuman(
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Registered(string indexed name, int8 indexed level, bytes data)",
+	)
+	transfer, _ := a.Event("Transfer")
+	from := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	to := types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c")
+	topics := []types.Hash{transfer.Topic, types.BytesToHash(from.Bytes()), types.BytesToHash(to.Bytes())}
+	data := words("00000000000000000000000000000000000000000000000000000000000003e8")
+
+	values, err := transfer.Unpack(topics, data)
+	if err != nil {
+		t.Fatalf("Event.Unpack() error = %v", err)
+	}
+	if values[0] != from || values[1] != to || values[2].(*big.Int).Int64() != 1000 {
+		t.Errorf("Event.Unpack() = %v", values)
+	}
+	var out struct {
+		From  types.Address
+		To    types.Address
+		Value *big.Int
+	}
+	if err := transfer.UnpackInto(&out, topics, data); err != nil || out.From != from || out.To != to || out.Value.Int64() != 1000 {
+		t.Errorf("Event.UnpackInto() = %+v, %v", out, err)
+	}
+
+	registered, _ := a.Event("Registered")
+	nameTopics, _ := registered.Topics("alice", -1)
+	regTopics := []types.Hash{*nameTopics[0], *nameTopics[1], *nameTopics[2]}
+	regData, _ := Arguments{{Type: MustParseType("bytes")}}.Pack([]byte{1, 2})
+	values, err = registered.Unpack(regTopics, regData)
+	if err != nil {
+		t.Fatalf("Event.Unpack() error = %v", err)
+	}
+	if values[0] != regTopics[1] || values[1].(*big.Int).Int64() != -1 || string(values[2].([]byte)) != "\x01\x02" {
+		t.Errorf("Event.Unpack() = %v", values)
+	}
+	var reg struct {
+		Name  types.Hash
+		Level int8
+		Data  []byte
+	}
+	if err := registered.UnpackInto(&reg, regTopics, regData); err != nil || reg.Name != regTopics[1] || reg.Level != -1 {
+		t.Errorf("Event.UnpackInto() = %+v, %v", reg, err)
+	}
+
+	invalid := []struct {
+		name   string
+		topics []types.Hash
+		data   []byte
+	}{
+		{"other event", append([]types.Hash{registered.Topic}, topics[1:]...), data},
+		{"missing topic", topics[:2], data},
+		{"no topics", nil, data},
+		{"short data", topics, data[:16]},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := transfer.Unpack(tt.topics, tt.data); err == nil {
+				t.Errorf("Event.Unpack() error = nil")
+			}
+		})
+	}
+}
+
+func TestEvent_Unpack_hashed(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{"string", "event E(string indexed a)"},
+		{"bytes", "event E(bytes indexed a)"},
+		{"slice", "event E(uint256[] indexed a)"},
+		{"static array", "event E(uint256[2] indexed a)"},
+		{"static tuple", "event E((uint256,uint256) indexed a)"},
+	}
+	hash := types.MustParseHash("0x9c0257114eb9399a2985f8e75dad7600c5d89fe3824ffa99ec1c3eb8bf3b0501")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := MustParseHuman(tt.signature).Events[0]
+			values, err := event.Unpack([]types.Hash{event.Topic, hash}, nil)
+			if err != nil || values[0] != hash {
+				t.Fatalf("Event.Unpack() = %v, %v, want %s", values, err, hash)
+			}
+			var out struct{ A types.Hash }
+			if err := event.UnpackInto(&out, []types.Hash{event.Topic, hash}, nil); err != nil || out.A != hash {
+				t.Errorf("Event.UnpackInto() = %+v, %v", out, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseHuman reads an ABI from human-readable signatures, one per entry,
+// as written in solidity:
+//
+//	"function balanceOf(address owner) view returns (uint256)"
+//	"event Transfer(address indexed from, address indexed to, uint256 value)"
+//	"error InsufficientBalance(uint256 available, uint256 required)"
+//	"constructor(string name, string symbol)"
+//
+// The function keyword may be left out, data locations are ignored.
+func ParseHuman(signatures ...string) (*ABI, error) {
+	a := &ABI{}
+	for _, signature := range signatures {
+		if err := a.addHuman(signature); err != nil {
+			return nil, fmt.Errorf("invalid signature %q: %w", signature, err)
+		}
+	}
+	return a, nil
+}
+
+// MustParseHuman is ParseHuman panicking on error, for constants.
+func MustParseHuman(signatures ...string) *ABI {
+	a, err := ParseHuman(signatures...)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a *ABI) addHuman(signature string) error {
+	s := strings.TrimSuffix(strings.TrimSpace(signature), ";")
+	kind := FUNCTION
+	if word, rest, found := strings.Cut(s, " "); found || strings.Contains(word, "(") {
+		keyword, _, _ := strings.Cut(word, "(")
+		switch keyword {
+		case FUNCTION, "event", "error":
+			kind, s = keyword, rest
+		case CONSTRUCTOR, FALLBACK, RECEIVE:
+			kind = keyword
+			s = strings.TrimPrefix(s, keyword)
+		}
+	}
+	s = strings.TrimSpace(s)
+	open := strings.Index(s, "(")
+	if open < 0 {
+		return errors.New("missing parameters")
+	}
+	end := closingParen(s[open:])
+	if end < 0 {
+		return errors.New("unbalanced parentheses")
+	}
+	end += open
+	name := strings.TrimSpace(s[:open])
+	if (name == "") != (kind == CONSTRUCTOR || kind == FALLBACK || kind == RECEIVE) || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid name %q", name)
+	}
+	inputs, err := parseParams(s[open+1:end], kind == "event")
+	if err != nil {
+		return err
+	}
+	modifiers := strings.TrimSpace(s[end+1:])
+
+	switch kind {
+	case "event":
+		anonymous := modifiers == "anonymous"
+		if modifiers != "" && !anonymous {
+			return fmt.Errorf("unexpected %q", modifiers)
+		}
+		a.Events = append(a.Events, NewEvent(name, inputs, anonymous))
+		return nil
+	case "error":
+		if modifiers != "" {
+			return fmt.Errorf("unexpected %q", modifiers)
+		}
+		a.Errors = append(a.Errors, NewError(name, inputs))
+		return nil
+	}
+
+	var outputs Arguments
+	if i := strings.Index(modifiers, "returns"); i >= 0 {
+		returns := strings.TrimSpace(modifiers[i+len("returns"):])
+		end := closingParen(returns)
+		if !strings.HasPrefix(returns, "(") || end != len(returns)-1 {
+			return fmt.Errorf("invalid returns %q", returns)
+		}
+		if outputs, err = parseParams(returns[1:end], false); err != nil {
+			return err
+		}
+		modifiers = modifiers[:i]
+	}
+	mutability := "nonpayable"
+	for _, word := range strings.Fields(modifiers) {
+		switch word {
+		case "pure", "view", "payable", "nonpayable":
+			mutability = word
+		case "external", "public", "internal", "private", "virtual", "override":
+		default:
+			return fmt.Errorf("unexpected %q", word)
+		}
+	}
+	return a.addMethod(NewMethod(kind, name, inputs, outputs, mutability))
+}
+
+// parseParams reads a comma separated parameter list, such as
+// "address indexed from, uint256[] memory ids, (address,uint256) order".
+func parseParams(list string, event bool) (Arguments, error) {
+	if strings.TrimSpace(list) == "" {
+		return Arguments{}, nil
+	}
+	var args Arguments
+	depth, start := 0, 0
+	for i := 0; i <= len(list); i++ {
+		if i < len(list) {
+			switch list[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if list[i] != ',' || depth > 0 {
+				continue
+			}
+		}
+		arg, err := parseParam(list[start:i], event)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		start = i + 1
+	}
+	return args, nil
+}
+
+func parseParam(s string, event bool) (Argument, error) {
+	s = strings.TrimSpace(s)
+	var typ, rest string
+	if strings.HasPrefix(s, "(") || strings.HasPrefix(s, "tuple(") {
+		open := strings.Index(s, "(")
+		end := closingParen(s[open:])
+		if end < 0 {
+			return Argument{}, fmt.Errorf("unbalanced parentheses in %q", s)
+		}
+		end += open + 1
+		// array suffixes
+		for end < len(s) && s[end] == '[' {
+			close := strings.Index(s[end:], "]")
+			if close < 0 {
+				return Argument{}, fmt.Errorf("invalid parameter %q", s)
+			}
+			end += close + 1
+		}
+		typ, rest = s[:end], s[end:]
+	} else {
+		typ, rest, _ = strings.Cut(s, " ")
+	}
+	if typ == "" {
+		return Argument{}, errors.New("empty parameter")
+	}
+	t, err := ParseType(typ)
+	if err != nil {
+		return Argument{}, err
+	}
+	arg := Argument{Type: t}
+	for _, word := range strings.Fields(rest) {
+		switch {
+		case word == "indexed" && event && !arg.Indexed && arg.Name == "":
+			arg.Indexed = true
+		case word == "memory" || word == "calldata" || word == "storage":
+		case word == "payable" && t.Kind == ADDRESS:
+		case arg.Name == "":
+			arg.Name = word
+		default:
+			return Argument{}, fmt.Errorf("unexpected %q in parameter %q", word, s)
+		}
+	}
+	return arg, nil
+}
@@ -0,0 +1,100 @@
+package abi
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseHuman(t *testing.T) {
+	a, err := ParseHuman(
+		"constructor(string memory name_, string memory symbol_)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"function transfer(address to, uint256 amount) external returns (bool)",
+		"transferFrom(address,address,uint256)",
+		"function getReserves() external view returns (uint112 reserve0, uint112 reserve1, uint32 blockTimestampLast)",
+		"function swap((address maker, uint256[2] amounts)[] calldata orders) payable",
+		"function withdraw(address payable to) public;",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Log(string message) anonymous",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"receive() external payable",
+		"fallback() external",
+	)
+	if err != nil {
+		t.Fatalf("ParseHuman() error = %v", err)
+	}
+	json, err := ParseJSON([]byte(erc20Json))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+	for _, name := range []string{"balanceOf", "transfer", "swap"} {
+		got, err := a.Method(name)
+		if err != nil {
+			t.Fatalf("ABI.Method(%s) error = %v", name, err)
+		}
+		want, _ := json.Method(name)
+		if got.Signature != want.Signature || got.Selector != want.Selector || got.Outputs.String() != want.Outputs.String() {
+			t.Errorf("ABI.Method(%s) = %s -> (%s), want %s -> (%s)", name, got.Signature, got.Outputs, want.Signature, want.Outputs)
+		}
+	}
+
+	methods := []struct {
+		name       string
+		signature  string
+		selector   string
+		mutability string
+		outputs    int
+	}{
+		{"balanceOf", "balanceOf(address)", "70a08231", "view", 1},
+		{"transferFrom", "transferFrom(address,address,uint256)", "23b872dd", "nonpayable", 0},
+		{"getReserves", "getReserves()", "0902f1ac", "view", 3},
+		{"swap", "swap((address,uint256[2])[])", "d72de5ad", "payable", 0},
+		{"withdraw", "withdraw(address)", "51cff8d9", "nonpayable", 0},
+	}
+	for _, tt := range methods {
+		m, err := a.Method(tt.name)
+		if err != nil {
+			t.Fatalf("ABI.Method(%s) error = %v", tt.name, err)
+		}
+		if m.Signature != tt.signature || hex.EncodeToString(m.Selector[:]) != tt.selector || m.StateMutability != tt.mutability || len(m.Outputs) != tt.outputs {
+			t.Errorf("ABI.Method(%s) = %s %x %s %d outputs, want %s %s %s %d", tt.name, m.Signature, m.Selector, m.StateMutability, len(m.Outputs), tt.signature, tt.selector, tt.mutability, tt.outputs)
+		}
+	}
+	if swap, _ := a.Method("swap"); swap.Inputs[0].Name != "orders" || swap.Inputs[0].Type.Elem.FieldNames[1] != "amounts" {
+		t.Errorf("swap inputs = %+v", swap.Inputs)
+	}
+	if reserves, _ := a.Method("getReserves"); reserves.Outputs[2].Name != "blockTimestampLast" {
+		t.Errorf("getReserves outputs = %+v", reserves.Outputs)
+	}
+
+	transfer, err := a.Event("Transfer")
+	if err != nil || transfer.Topic.Hex() != "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" || !transfer.Inputs[1].Indexed || transfer.Inputs[2].Indexed {
+		t.Errorf("ABI.Event(Transfer) = %+v, %v", transfer, err)
+	}
+	if log, err := a.Event("Log"); err != nil || !log.Anonymous {
+		t.Errorf("ABI.Event(Log) = %+v, %v", log, err)
+	}
+	if e, err := a.Error("InsufficientBalance"); err != nil || e.Signature != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("ABI.Error() = %+v, %v", e, err)
+	}
+	if a.Constructor == nil || a.Constructor.Inputs[1].Name != "symbol_" || a.Receive == nil || a.Receive.StateMutability != "payable" || a.Fallback == nil {
+		t.Errorf("ParseHuman() constructor = %+v, receive = %+v, fallback = %+v", a.Constructor, a.Receive, a.Fallback)
+	}
+
+	for _, invalid := range []string{
+		"function balanceOf",
+		"function (address)",
+		"function f(address) returns uint256",
+		"function f(uint7)",
+		"function f(address indexed a)",
+		"function f() cheap",
+		"event E(uint256 a) indexed",
+		"error E() view",
+		"function f(address a b)",
+		"function f((address,uint256)",
+	} {
+		if _, err := ParseHuman(invalid); err == nil {
+			t.Errorf("ParseHuman(%q) error = nil", invalid)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Package abi encodes and decodes contract calls, results, errors and
+// events following the Solidity ABI specification. Contract interfaces are
+// read from json ABIs or from human-readable signatures such as
+// "function balanceOf(address owner) view returns (uint256)".
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the family of an ABI type.
+type Kind int
+
+const (
+	UINT        Kind = iota // uintN
+	INT                     // intN
+	ADDRESS                 // address
+	BOOL                    // bool
+	FIXED_BYTES             // bytesN
+	BYTES                   // bytes
+	STRING                  // string
+	SLICE                   // T[]
+	ARRAY                   // T[k]
+	TUPLE                   // (T1,T2,...)
+)
+
+// Type is a parsed ABI type.
+type Type struct {
+	Kind       Kind
+	Size       int      // bits of uintN and intN, length of bytesN and T[k]
+	Elem       *Type    // element type of T[] and T[k]
+	Fields     []Type   // tuple fields
+	FieldNames []string // tuple field names, empty when not named
+}
+
+// ParseType reads a type as written in signatures: "uint256",
+// "bytes32[]" or "(address,uint256)[2]". Tuples may also be written
+// "tuple(...)" and name their fields, "(address to, uint256 amount)".
+func ParseType(s string) (Type, error) {
+	typ := strings.TrimSpace(s)
+	if strings.HasPrefix(typ, "tuple(") {
+		typ = typ[len("tuple"):]
+	}
+	if strings.HasPrefix(typ, "(") {
+		end := closingParen(typ)
+		if end < 0 {
+			return Type{}, fmt.Errorf("invalid abi type %q: unbalanced parentheses", s)
+		}
+		params, err := parseParams(typ[1:end], false)
+		if err != nil {
+			return Type{}, fmt.Errorf("invalid abi type %q: %w", s, err)
+		}
+		tuple := Type{Kind: TUPLE}
+		for _, p := range params {
+			tuple.Fields = append(tuple.Fields, p.Type)
+			tuple.FieldNames = append(tuple.FieldNames, p.Name)
+		}
+		return withArraySuffix(tuple, typ[end+1:], s)
+	}
+	if i := strings.Index(typ, "["); i >= 0 {
+		elem, err := ParseType(typ[:i])
+		if err != nil {
+			return Type{}, err
+		}
+		return withArraySuffix(elem, typ[i:], s)
+	}
+	return parseElementary(typ)
+}
+
+// MustParseType is ParseType panicking on error, for constants.
+func MustParseType(s string) Type {
+	t, err := ParseType(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// newJsonType returns the type of a json ABI entry, whose tuples have
+// their fields in components.
+func newJsonType(typ string, components []jsonArgument) (Type, error) {
+	typ = strings.TrimSpace(typ)
+	if !strings.HasPrefix(typ, "tuple") {
+		return ParseType(typ)
+	}
+	tuple := Type{Kind: TUPLE}
+	for _, c := range components {
+		field, err := newJsonType(c.Type, c.Components)
+		if err != nil {
+			return Type{}, err
+		}
+		tuple.Fields = append(tuple.Fields, field)
+		tuple.FieldNames = append(tuple.FieldNames, c.Name)
+	}
+	return withArraySuffix(tuple, typ[len("tuple"):], typ)
+}
+
+// withArraySuffix wraps elem in the arrays of suffix, such as "[2][]",
+// innermost first.
+func withArraySuffix(elem Type, suffix string, s string) (Type, error) {
+	t := elem
+	for suffix != "" {
+		end := strings.Index(suffix, "]")
+		if suffix[0] != '[' || end < 0 {
+			return Type{}, fmt.Errorf("invalid abi type %q", s)
+		}
+		inner := t
+		if end == 1 {
+			t = Type{Kind: SLICE, Elem: &inner}
+		} else {
+			length, err := strconv.Atoi(suffix[1:end])
+			if err != nil || length <= 0 {
+				return Type{}, fmt.Errorf("invalid abi array length in %q", s)
+			}
+			t = Type{Kind: ARRAY, Size: length, Elem: &inner}
+		}
+		suffix = suffix[end+1:]
+	}
+	return t, nil
+}
+
+func parseElementary(typ string) (Type, error) {
+	switch {
+	case typ == "address":
+		return Type{Kind: ADDRESS}, nil
+	case typ == "bool":
+		return Type{Kind: BOOL}, nil
+	case typ == "string":
+		return Type{Kind: STRING}, nil
+	case typ == "bytes":
+		return Type{Kind: BYTES}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return Type{}, fmt.Errorf("invalid abi type %q", typ)
+		}
+		return Type{Kind: FIXED_BYTES, Size: size}, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		kind, bits := UINT, strings.TrimPrefix(typ, "uint")
+		if !strings.HasPrefix(typ, "uint") {
+			kind, bits = INT, strings.TrimPrefix(typ, "int")
+		}
+		if bits == "" {
+			return Type{Kind: kind, Size: 256}, nil
+		}
+		size, err := strconv.Atoi(bits)
+		if err != nil || size < 8 || size > 256 || size%8 != 0 {
+			return Type{}, fmt.Errorf("invalid abi type %q", typ)
+		}
+		return Type{Kind: kind, Size: size}, nil
+	}
+	return Type{}, fmt.Errorf("unsupported abi type %q", typ)
+}
+
+// String returns the canonical type, as written in the signatures hashed
+// into selectors: uint is uint256 and tuples are spelled out.
+func (t Type) String() string {
+	switch t.Kind {
+	case UINT:
+		return fmt.Sprintf("uint%d", t.Size)
+	case INT:
+		return fmt.Sprintf("int%d", t.Size)
+	case ADDRESS:
+		return "address"
+	case BOOL:
+		return "bool"
+	case FIXED_BYTES:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case BYTES:
+		return "bytes"
+	case STRING:
+		return "string"
+	case SLICE:
+		return t.Elem.String() + "[]"
+	case ARRAY:
+		return fmt.Sprintf("%s[%d]", t.Elem, t.Size)
+	case TUPLE:
+		fields := make([]string, len(t.Fields))
+		for i, f := range t.Fields {
+			fields[i] = f.String()
+		}
+		return "(" + strings.Join(fields, ",") + ")"
+	}
+	return "invalid"
+}
+
+// Dynamic tells if values of t are encoded out of the head of their
+// enclosing tuple, behind an offset.
+func (t Type) Dynamic() bool {
+	switch t.Kind {
+	case BYTES, STRING, SLICE:
+		return true
+	case ARRAY:
+		return t.Elem.Dynamic()
+	case TUPLE:
+		for _, f := range t.Fields {
+			if f.Dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HashedWhenIndexed tells if an indexed event input of type t is logged as
+// the keccak256 hash of its value: strings, bytes, arrays and tuples are,
+// static ones included.
+func (t Type) HashedWhenIndexed() bool {
+	switch t.Kind {
+	case STRING, BYTES, SLICE, ARRAY, TUPLE:
+		return true
+	}
+	return false
+}
+
+// headSize is the room taken by t in the head of its enclosing tuple.
+func (t Type) headSize() int {
+	if t.Dynamic() {
+		return 32
+	}
+	switch t.Kind {
+	case ARRAY:
+		return t.Size * t.Elem.headSize()
+	case TUPLE:
+		size := 0
+		for _, f := range t.Fields {
+			size += f.headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+func repeatType(t Type, n int) []Type {
+	ts := make([]Type, n)
+	for i := range ts {
+		ts[i] = t
+	}
+	return ts
+}
+
+// closingParen returns the index of the parenthesis closing the one s
+// starts with, -1 when unbalanced.
+func closingParen(s string) int {
+	depth := 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
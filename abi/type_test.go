@@ -0,0 +1,69 @@
+package abi
+
+import "testing"
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      string
+		wantDyn   bool
+		wantKind  Kind
+		wantNames []string
+	}{
+		{in: "uint", want: "uint256", wantKind: UINT},
+		{in: "int8", want: "int8", wantKind: INT},
+		{in: "address", want: "address", wantKind: ADDRESS},
+		{in: "bytes32", want: "bytes32", wantKind: FIXED_BYTES},
+		{in: "bytes", want: "bytes", wantDyn: true, wantKind: BYTES},
+		{in: "string[]", want: "string[]", wantDyn: true, wantKind: SLICE},
+		{in: "uint256[2][]", want: "uint256[2][]", wantDyn: true, wantKind: SLICE},
+		{in: "bool[3]", want: "bool[3]", wantKind: ARRAY},
+		{in: "string[2]", want: "string[2]", wantDyn: true, wantKind: ARRAY},
+		{in: "(address,uint)", want: "(address,uint256)", wantKind: TUPLE, wantNames: []string{"", ""}},
+		{in: "tuple(address to, uint256 amount)[]", want: "(address,uint256)[]", wantDyn: true, wantKind: SLICE},
+		{in: "(address to, (bytes data, uint8 v) sig)", want: "(address,(bytes,uint8))", wantDyn: true, wantKind: TUPLE, wantNames: []string{"to", "sig"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseType(tt.in)
+			if err != nil {
+				t.Fatalf("ParseType() error = %v", err)
+			}
+			if got.String() != tt.want || got.Dynamic() != tt.wantDyn || got.Kind != tt.wantKind {
+				t.Errorf("ParseType() = %s dynamic %v kind %d, want %s dynamic %v kind %d", got, got.Dynamic(), got.Kind, tt.want, tt.wantDyn, tt.wantKind)
+			}
+			if tt.wantNames != nil && len(got.FieldNames) != len(tt.wantNames) {
+				t.Fatalf("ParseType() field names = %q, want %q", got.FieldNames, tt.wantNames)
+			}
+			for i, name := range tt.wantNames {
+				if got.FieldNames[i] != name {
+					t.Errorf("ParseType() field names = %q, want %q", got.FieldNames, tt.wantNames)
+				}
+			}
+		})
+	}
+
+	for _, in := range []string{"", "uint7", "uint264", "int0", "bytes0", "bytes33", "fixed128x18", "uint256[0]", "uint256[", "uint256]", "(address", "(address,)", "function"} {
+		t.Run("invalid "+in, func(t *testing.T) {
+			if got, err := ParseType(in); err == nil {
+				t.Errorf("ParseType() = %s, error = nil", got)
+			}
+		})
+	}
+}
+
+func TestType_headSize(t *testing.T) {
+	tests := map[string]int{
+		"uint8":              32,
+		"bytes":              32,
+		"uint256[3]":         96,
+		"(address,bool)[2]":  128,
+		"(address,string)[]": 32,
+		"(address,bytes)":    32,
+	}
+	for in, want := range tests {
+		if got := MustParseType(in).headSize(); got != want {
+			t.Errorf("%s headSize() = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrorMissingBatchResponse is reported on a batch item the server did not
+// answer.
+var ErrorMissingBatchResponse = AlchemyApiError{
+	Code:    -32603,
+	Message: "no response received for batch item",
+}
+
+// ExecuteBatch sends every request of batch as a single JSON-RPC batch
+// array when the client Transport supports it (the default https one does),
+// one request at a time otherwise.
+//
+// Responses are matched back by id whatever order the server answers in and
+// are returned in the order of batch with the caller id restored.
+// A JSON-RPC error on one item is reported in that item's Error field and
+// does not fail the whole batch; the returned error is only set when the
+// batch itself could not be sent or decoded.
+func ExecuteBatch[P any, R any](ctx context.Context, client *AlchemyClient, batch []JsonParams[P]) ([]*AlchemyResponse[R], error) {
+	if len(batch) == 0 {
+		return []*AlchemyResponse[R]{}, nil
+	}
+
+	elems := make([]BatchElem, len(batch))
+	methods := make([]string, len(batch))
+	for i, jsonP := range batch {
+		elems[i] = BatchElem{
+			Method: jsonP.Method,
+			Params: toInterfaces(jsonP.Params),
+		}
+		methods[i] = jsonP.Method
+	}
+
+	transport := client.transport()
+	if err := client.waitLimiter(ctx, transport, methods...); err != nil {
+		return nil, err
+	}
+
+	if err := callBatch(ctx, transport, elems); err != nil {
+		return nil, err
+	}
+
+	responses := make([]*AlchemyResponse[R], len(batch))
+	for i, elem := range elems {
+		resp := &AlchemyResponse[R]{
+			Id:      batch[i].Id,
+			Jsonrpc: "2.0",
+		}
+		var apiErr *AlchemyApiError
+		if errors.As(elem.Error, &apiErr) {
+			resp.Error = *apiErr
+		} else if len(elem.Result) > 0 {
+			if err := json.Unmarshal(elem.Result, &resp.Result); err != nil {
+				return nil, &AlchemyClientError{"ExecuteBatch", err.Error()}
+			}
+		}
+		responses[i] = resp
+	}
+	return responses, nil
+}
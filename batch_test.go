@@ -0,0 +1,140 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeBatchServer answers a batch in reverse order, returning a JSON-RPC
+// error for eth_getCode items and the method name as result otherwise.
+func fakeBatchServer() *httptest.Server {
+	responseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []JsonParams[interface{}]
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`))
+			return
+		}
+		resps := make([]AlchemyResponse[interface{}], 0, len(reqs))
+		for i := len(reqs) - 1; i >= 0; i-- {
+			resp := AlchemyResponse[interface{}]{Id: reqs[i].Id, Jsonrpc: "2.0"}
+			if reqs[i].Method == "eth_getCode" {
+				resp.Error = ErrorTooShortAddress
+			} else if reqs[i].Method == "eth_dropped" {
+				continue
+			} else {
+				resp.Result = reqs[i].Method
+			}
+			resps = append(resps, resp)
+		}
+		result, _ := json.Marshal(resps)
+		w.WriteHeader(http.StatusOK)
+		w.Write(result)
+	})
+	return httptest.NewServer(responseHandler)
+}
+
+func TestExecuteBatch(t *testing.T) {
+	ts := fakeBatchServer()
+	defer ts.Close()
+
+	c := &AlchemyClient{
+		ApiKey:       "key",
+		BaseUrlApiV2: ts.URL,
+		MaxRetry:     1,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		batch   []JsonParams[string]
+		want    []*AlchemyResponse[string]
+		wantErr bool
+	}{
+		{
+			name:  "empty batch",
+			batch: []JsonParams[string]{},
+			want:  []*AlchemyResponse[string]{},
+		},
+		{
+			name: "out of order answers with per item error",
+			batch: []JsonParams[string]{
+				{Id: 7, Jsonrpc: "2.0", Method: "eth_getStorageAt", Params: []string{"0x01", "0x0", "latest"}},
+				{Id: 7, Jsonrpc: "2.0", Method: "eth_getCode", Params: []string{"0x01", "latest"}},
+				{Id: 9, Method: "eth_blockNumber"},
+				{Id: 10, Method: "eth_dropped"},
+			},
+			want: []*AlchemyResponse[string]{
+				{Id: 7, Jsonrpc: "2.0", Result: "eth_getStorageAt"},
+				{Id: 7, Jsonrpc: "2.0", Error: ErrorTooShortAddress},
+				{Id: 9, Jsonrpc: "2.0", Result: "eth_blockNumber"},
+				{Id: 10, Jsonrpc: "2.0", Error: ErrorMissingBatchResponse},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExecuteBatch[string, string](context.Background(), c, tt.batch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecuteBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExecuteBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteBatch_rejected(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Must be authenticated!"}}`))
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{
+		ApiKey:       "key",
+		BaseUrlApiV2: ts.URL,
+		MaxRetry:     1,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+	batch := []JsonParams[string]{{Method: "eth_blockNumber"}}
+	_, err := ExecuteBatch[string, string](context.Background(), c, batch)
+	if apiErr, ok := err.(*AlchemyApiError); !ok || *apiErr != ErrorMustBeAuthenticated {
+		t.Errorf("ExecuteBatch() error = %v, want %v", err, ErrorMustBeAuthenticated)
+	}
+}
+
+func TestExecuteBatch_statusWithoutError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":null}`))
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{
+		ApiKey:       "key",
+		BaseUrlApiV2: ts.URL,
+		MaxRetry:     1,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+	batch := []JsonParams[string]{{Method: "eth_blockNumber"}}
+	_, err := ExecuteBatch[string, string](context.Background(), c, batch)
+	var statusErr *HttpStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("ExecuteBatch() error = %v, want HTTP %d", err, http.StatusBadRequest)
+	}
+}
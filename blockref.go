@@ -0,0 +1,98 @@
+package goalchemysdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// BlockRef identifies a block by tag, number or hash, see Tag, Number
+// and Hash. A BlockTag is a BlockRef too, so is a hex block number held in
+// a BlockTag.
+type BlockRef interface {
+	blockRef()
+}
+
+func (BlockTag) blockRef() {}
+
+// BlockNumber is a block number, sent as a hex quantity.
+type BlockNumber uint64
+
+func (BlockNumber) blockRef() {}
+
+func (n BlockNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("0x%x", uint64(n)))
+}
+
+func (n *BlockNumber) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil || !strings.HasPrefix(s, "0x") {
+		return fmt.Errorf("invalid block number %q", s)
+	}
+	*n = BlockNumber(v)
+	return nil
+}
+
+// BlockHashParam selects a block by hash (EIP-1898). With RequireCanonical
+// the request fails if the block is not in the canonical chain.
+type BlockHashParam struct {
+	BlockHash        types.Hash `json:"blockHash"`
+	RequireCanonical bool       `json:"requireCanonical,omitempty"`
+}
+
+func (BlockHashParam) blockRef() {}
+
+// Tag refers to a block by tag, such as LATEST or FINALIZED.
+func Tag(tag BlockTag) BlockRef {
+	return tag
+}
+
+// Number refers to a block by number.
+func Number(n uint64) BlockRef {
+	return BlockNumber(n)
+}
+
+// Hash refers to a block by hash. With requireCanonical the request fails
+// if the block is not in the canonical chain.
+func Hash(hash types.Hash, requireCanonical bool) BlockRef {
+	return BlockHashParam{BlockHash: hash, RequireCanonical: requireCanonical}
+}
+
+// blockParam returns ref as sent to methods taking a block number or an
+// EIP-1898 block hash, latest when ref is unset.
+func blockParam(ref BlockRef) BlockRef {
+	switch r := ref.(type) {
+	case nil:
+		return LATEST
+	case BlockTag:
+		if r == "" {
+			return LATEST
+		}
+		if hash, err := types.ParseHash(string(r)); err == nil && isBlockHash(string(r)) {
+			return BlockHashParam{BlockHash: hash}
+		}
+	}
+	return ref
+}
+
+// blockNumberParam returns ref as sent to methods which only take a block
+// tag or number, such as the range of eth_getLogs.
+func blockNumberParam(method string, ref BlockRef) (BlockRef, error) {
+	ref = blockParam(ref)
+	if _, ok := ref.(BlockHashParam); ok {
+		return nil, &AlchemyClientError{method, "a block hash is not accepted, give a tag or a number"}
+	}
+	return ref, nil
+}
+
+// isBlockHash tells a 32 bytes hash from a hex block number.
+func isBlockHash(s string) bool {
+	return len(s) == 66 && strings.HasPrefix(s, "0x")
+}
@@ -0,0 +1,163 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+func TestBlockRef_MarshalJSON(t *testing.T) {
+	hash := "0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"
+	tests := []struct {
+		name string
+		ref  BlockRef
+		want string
+	}{
+		{"tag", Tag(FINALIZED), `"finalized"`},
+		{"block tag", SAFE, `"safe"`},
+		{"number", Number(1207), `"0x4b7"`},
+		{"zero number", Number(0), `"0x0"`},
+		{"hash", Hash(types.MustParseHash(hash), false), `{"blockHash":"` + hash + `"}`},
+		{"canonical hash", Hash(types.MustParseHash(hash), true), `{"blockHash":"` + hash + `","requireCanonical":true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.ref)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockNumber_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    BlockNumber
+		wantErr bool
+	}{
+		{"hex", `"0x4b7"`, 1207, false},
+		{"no prefix", `"4b7"`, 0, true},
+		{"not a string", `1207`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got BlockNumber
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BlockNumber.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BlockNumber.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogsParam_MarshalJSON(t *testing.T) {
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	blockHash := types.MustParseHash("0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2")
+	tests := []struct {
+		name    string
+		lp      LogsParam
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "range",
+			lp:   LogsParam{Address: &address, FromBlock: Number(16), ToBlock: LATEST},
+			want: `{"address":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","fromBlock":"0x10","toBlock":"latest"}`,
+		},
+		{
+			name: "no range",
+			lp:   LogsParam{BlockHash: &blockHash, Topics: []*types.Hash{nil, &blockHash}},
+			want: `{"blockHash":"0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2","topics":[null,"0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"]}`,
+		},
+		{
+			name: "zero value tags",
+			lp:   LogsParam{Address: &address, FromBlock: BlockTag(""), ToBlock: BlockTag("")},
+			want: `{"address":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}`,
+		},
+		{
+			name: "zero value",
+			lp:   LogsParam{},
+			want: `{}`,
+		},
+		{
+			name:    "range by hash",
+			lp:      LogsParam{FromBlock: Hash(blockHash, false)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.lp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LogsParam.MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("LogsParam.MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_BlockRef_Params(t *testing.T) {
+	hash := "0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	slot := "0x0000000000000000000000000000000000000000000000000000000000000000"
+	sent := ""
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(`"` + slot + `"`), err
+		}),
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{
+			name: "getCode number",
+			call: func() error { _, err := c.Eth_getCode(ctx, address, Number(16)); return err },
+			want: `eth_getCode["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","0x10"]`,
+		},
+		{
+			name: "getCode unset",
+			call: func() error { _, err := c.Eth_getCode(ctx, address, nil); return err },
+			want: `eth_getCode["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","latest"]`,
+		},
+		{
+			name: "getStorageAt hash",
+			call: func() error {
+				_, err := c.Eth_getStorageAt(ctx, address, types.Hash{}, Hash(types.MustParseHash(hash), true))
+				return err
+			},
+			want: `eth_getStorageAt["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","` + slot + `",{"blockHash":"` + hash + `","requireCanonical":true}]`,
+		},
+		{
+			name: "getStorageAt hash in tag",
+			call: func() error { _, err := c.Eth_getStorageAt(ctx, address, types.Hash{}, BlockTag(hash)); return err },
+			want: `eth_getStorageAt["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","` + slot + `",{"blockHash":"` + hash + `"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			if sent != tt.want {
+				t.Errorf("sent %s, want %s", sent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,546 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+)
+
+// identifiers the generated methods use for themselves
+var reservedParams = map[string]bool{
+	"ctx": true, "blk": true, "fromBlock": true, "toBlock": true, "t": true, "out": true,
+	"err": true, "values": true, "m": true, "events": true, "payload": true, "bin": true, "args": true,
+	"abi": true, "types": true, "big": true, "context": true, "goalchemysdk": true,
+}
+
+// bindOptions is what a binding is generated from.
+type bindOptions struct {
+	Package string
+	Type    string
+	Abi     []byte // json ABI, or a hardhat or foundry artifact
+	Bin     string // creation bytecode, hex encoded, optional
+}
+
+type bindParam struct {
+	Name   string // Go identifier
+	AbiTag string // abi input name, empty when unnamed
+	GoType string
+}
+
+type bindMethod struct {
+	GoName     string
+	Signature  string
+	Inputs     []bindParam
+	Outputs    []bindParam
+	OutputType string // Go type returned, a struct for many outputs
+	Zero       string // zero value of OutputType
+}
+
+type bindEvent struct {
+	GoName    string
+	Signature string
+	Fields    []bindParam
+	Filters   []bindParam // indexed inputs that can be filtered on
+	Topics    []string    // one per indexed input, nil when not filtered on
+}
+
+type bindStruct struct {
+	Name   string
+	Doc    string
+	Fields []bindParam
+}
+
+type binding struct {
+	Package     string
+	Type        string
+	AbiJson     string
+	Bin         string
+	Constructor *bindMethod
+	Calls       []bindMethod // view and pure functions
+	Transacts   []bindMethod // functions changing state
+	Events      []bindEvent
+	Structs     []bindStruct
+	UsesBig     bool
+
+	structs map[string]string // tuple type and field names to struct name
+	names   map[string]bool   // package level names taken
+}
+
+// generate returns the formatted source of the binding.
+func generate(opts bindOptions) ([]byte, error) {
+	abiJson, bin, err := readArtifact(opts.Abi)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Bin != "" {
+		bin = opts.Bin
+	}
+	if bin != "" && !strings.HasPrefix(bin, "0x") {
+		bin = "0x" + bin
+	}
+	a, err := abi.ParseJSON(abiJson)
+	if err != nil {
+		return nil, err
+	}
+	if !token.IsIdentifier(opts.Type) || !token.IsExported(opts.Type) {
+		return nil, fmt.Errorf("invalid type name %q, want an exported Go identifier", opts.Type)
+	}
+	if !token.IsIdentifier(opts.Package) {
+		return nil, fmt.Errorf("invalid package name %q", opts.Package)
+	}
+
+	b := &binding{
+		Package: opts.Package,
+		Type:    opts.Type,
+		AbiJson: strconv.Quote(string(abiJson)),
+		Bin:     bin,
+		structs: map[string]string{},
+		names:   map[string]bool{},
+	}
+	for _, name := range []string{"", "ABI", "Bin", "DeployData"} {
+		b.names[opts.Type+name] = true
+	}
+	b.names["New"+opts.Type] = true
+	eventNames := map[string]bool{}
+	for _, e := range a.Events {
+		name := uniqueName(identifier(e.Name), eventNames)
+		b.names[opts.Type+name] = true
+		b.names[opts.Type+name+"Iterator"] = true
+	}
+
+	if a.Constructor != nil && bin != "" {
+		ctor := b.method(*a.Constructor, "Deploy")
+		b.Constructor = &ctor
+	}
+	// methods promoted from the embedded contract
+	methodNames := map[string]bool{"Contract": true, "Address": true, "ABI": true, "Call": true, "CallAt": true, "CallInto": true, "Filter": true}
+	for _, m := range a.Methods {
+		m.Name = uniqueName(identifier(m.Name), methodNames)
+		bm := b.method(m, m.Name)
+		if m.StateMutability == "view" || m.StateMutability == "pure" {
+			b.Calls = append(b.Calls, bm)
+		} else {
+			b.Transacts = append(b.Transacts, bm)
+		}
+	}
+	eventNames = map[string]bool{}
+	for _, e := range a.Events {
+		b.Events = append(b.Events, b.event(e, uniqueName(identifier(e.Name), eventNames)))
+	}
+
+	var src bytes.Buffer
+	if err := bindTemplate.Execute(&src, b); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w\n%s", err, src.Bytes())
+	}
+	return formatted, nil
+}
+
+// readArtifact returns the json ABI of an ABI or of an artifact, with the
+// bytecode of the artifact.
+func readArtifact(data []byte) ([]byte, string, error) {
+	var artifact struct {
+		Abi      json.RawMessage `json:"abi"`
+		Bytecode json.RawMessage `json:"bytecode"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, data); err != nil {
+			return nil, "", fmt.Errorf("invalid abi json: %w", err)
+		}
+		return compact.Bytes(), "", nil
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, "", fmt.Errorf("invalid artifact json: %w", err)
+	}
+	if artifact.Abi == nil {
+		return nil, "", fmt.Errorf("artifact without abi")
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, artifact.Abi); err != nil {
+		return nil, "", fmt.Errorf("invalid abi json: %w", err)
+	}
+	// hardhat writes the bytecode as a string, foundry as an object
+	var bin string
+	var object struct {
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(artifact.Bytecode, &bin); err != nil && json.Unmarshal(artifact.Bytecode, &object) == nil {
+		bin = object.Object
+	}
+	if bin == "0x" {
+		bin = ""
+	}
+	return compact.Bytes(), bin, nil
+}
+
+func (b *binding) method(m abi.Method, goName string) bindMethod {
+	bm := bindMethod{GoName: goName, Signature: m.Signature}
+	taken := map[string]bool{}
+	for i, in := range m.Inputs {
+		bm.Inputs = append(bm.Inputs, bindParam{
+			Name:   paramName(in.Name, i, taken),
+			GoType: b.goType(in.Type, goName+identifier(in.Name)),
+		})
+	}
+	fields := map[string]bool{}
+	for i, out := range m.Outputs {
+		bm.Outputs = append(bm.Outputs, bindParam{
+			Name:   fieldName(out.Name, i, fields),
+			AbiTag: out.Name,
+			GoType: b.goType(out.Type, goName+identifier(out.Name)),
+		})
+	}
+	switch len(bm.Outputs) {
+	case 0:
+	case 1:
+		bm.OutputType = bm.Outputs[0].GoType
+		bm.Zero = zeroValue(bm.OutputType)
+	default:
+		name := b.typeName(b.Type + goName + "Output")
+		b.Structs = append(b.Structs, bindStruct{Name: name, Doc: "holds the outputs of " + m.Signature, Fields: bm.Outputs})
+		bm.OutputType, bm.Zero = name, name+"{}"
+	}
+	return bm
+}
+
+func (b *binding) event(e abi.Event, goName string) bindEvent {
+	be := bindEvent{GoName: goName, Signature: e.Signature}
+	fields := map[string]bool{"Raw": true}
+	params := map[string]bool{}
+	for i, in := range e.Inputs {
+		goType := b.goType(in.Type, goName+identifier(in.Name))
+		fieldType := goType
+		if in.Indexed && in.Type.HashedWhenIndexed() {
+			// only the hash is logged
+			fieldType = "[32]byte"
+		}
+		be.Fields = append(be.Fields, bindParam{Name: fieldName(in.Name, i, fields), AbiTag: in.Name, GoType: fieldType})
+		if !in.Indexed {
+			continue
+		}
+		switch in.Type.Kind {
+		case abi.SLICE, abi.ARRAY, abi.TUPLE:
+			be.Topics = append(be.Topics, "nil")
+		default:
+			// strings and bytes are hashed into their topic
+			p := bindParam{Name: paramName(in.Name, i, params), GoType: nilable(goType)}
+			be.Filters = append(be.Filters, p)
+			be.Topics = append(be.Topics, p.Name)
+		}
+	}
+	// trailing topics matching anything are left out
+	for len(be.Topics) > 0 && be.Topics[len(be.Topics)-1] == "nil" {
+		be.Topics = be.Topics[:len(be.Topics)-1]
+	}
+	return be
+}
+
+// nilable returns goType, or a pointer to it when it has no nil value to
+// match any topic with.
+func nilable(goType string) string {
+	if strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		return goType
+	}
+	return "*" + goType
+}
+
+// goType returns the Go type of t, generating the structs of tuples named
+// after hint.
+func (b *binding) goType(t abi.Type, hint string) string {
+	switch t.Kind {
+	case abi.UINT, abi.INT:
+		prefix := "uint"
+		if t.Kind == abi.INT {
+			prefix = "int"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, t.Size)
+		}
+		b.UsesBig = true
+		return "*big.Int"
+	case abi.ADDRESS:
+		return "types.Address"
+	case abi.BOOL:
+		return "bool"
+	case abi.STRING:
+		return "string"
+	case abi.BYTES:
+		return "[]byte"
+	case abi.FIXED_BYTES:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.SLICE:
+		return "[]" + b.goType(*t.Elem, hint)
+	case abi.ARRAY:
+		return fmt.Sprintf("[%d]%s", t.Size, b.goType(*t.Elem, hint))
+	}
+
+	key := t.String() + " " + strings.Join(t.FieldNames, ",")
+	if name, ok := b.structs[key]; ok {
+		return name
+	}
+	name := b.typeName(b.Type + hint)
+	b.structs[key] = name
+	s := bindStruct{Name: name, Doc: "is the abi tuple " + t.String()}
+	fields := map[string]bool{}
+	for i, f := range t.Fields {
+		fieldAbiName := ""
+		if i < len(t.FieldNames) {
+			fieldAbiName = t.FieldNames[i]
+		}
+		s.Fields = append(s.Fields, bindParam{
+			Name:   fieldName(fieldAbiName, i, fields),
+			AbiTag: fieldAbiName,
+			GoType: b.goType(f, hint+identifier(fieldAbiName)),
+		})
+	}
+	b.Structs = append(b.Structs, s)
+	return name
+}
+
+// typeName returns name, numbered when taken.
+func (b *binding) typeName(name string) string {
+	return uniqueName(name, b.names)
+}
+
+// identifier returns name as an exported Go identifier, empty when it
+// has no letter.
+func identifier(name string) string {
+	var id []rune
+	for _, r := range strings.TrimLeft(name, "_$") {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r) && len(id) > 0:
+			id = append(id, r)
+		case r == '_' || r == '$':
+			id = append(id, '_')
+		}
+	}
+	if len(id) == 0 {
+		return ""
+	}
+	id[0] = unicode.ToUpper(id[0])
+	return string(id)
+}
+
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 0; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// paramName returns the Go parameter of the abi input name, argN when
+// unnamed or clashing.
+func paramName(name string, i int, taken map[string]bool) string {
+	id := identifier(name)
+	if id != "" {
+		id = strings.ToLower(id[:1]) + id[1:]
+	}
+	if id == "" || token.IsKeyword(id) || reservedParams[id] || taken[id] {
+		id = fmt.Sprintf("arg%d", i)
+	}
+	taken[id] = true
+	return id
+}
+
+// fieldName returns the struct field of the abi input name, FieldN when
+// unnamed.
+func fieldName(name string, i int, taken map[string]bool) string {
+	id := identifier(name)
+	if id == "" {
+		id = fmt.Sprintf("Field%d", i)
+	}
+	return uniqueName(id, taken)
+}
+
+func zeroValue(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "*"), strings.HasPrefix(goType, "[]"):
+		return "nil"
+	case goType == "bool":
+		return "false"
+	case goType == "string":
+		return `""`
+	case strings.HasPrefix(goType, "uint"), strings.HasPrefix(goType, "int"):
+		return "0"
+	}
+	return goType + "{}"
+}
+
+var bindTemplate = template.Must(template.New("binding").Funcs(template.FuncMap{
+	"lower": func(s string) string { return strings.ToLower(s[:1]) + s[1:] },
+	"params": func(ps []bindParam) string {
+		decl := make([]string, len(ps))
+		for i, p := range ps {
+			decl[i] = p.Name + " " + p.GoType
+		}
+		return strings.Join(decl, ", ")
+	},
+	"args": func(ps []bindParam) string {
+		names := make([]string, len(ps))
+		for i, p := range ps {
+			names[i] = p.Name
+		}
+		return strings.Join(names, ", ")
+	},
+	"tag": func(p bindParam) string {
+		if p.AbiTag == "" {
+			return ""
+		}
+		return "`abi:" + strconv.Quote(p.AbiTag) + "`"
+	},
+	"join": strings.Join,
+}).Parse(`// Code generated by alchemy-abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- if .UsesBig}}
+	"math/big"
+{{- end}}
+
+	goalchemysdk "github.com/nabetse00/go-alchemy-sdk"
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// {{.Type}}ABI is the json ABI of {{.Type}}.
+const {{.Type}}ABI = {{.AbiJson}}
+{{if .Bin}}
+// {{.Type}}Bin is the creation bytecode of {{.Type}}.
+const {{.Type}}Bin = "{{.Bin}}"
+{{end}}
+var {{lower .Type}}Abi = func() *abi.ABI {
+	a, err := abi.ParseJSON([]byte({{.Type}}ABI))
+	if err != nil {
+		panic(err)
+	}
+	return a
+}()
+
+// {{.Type}} is a binding of the {{.Type}} contract.
+type {{.Type}} struct {
+	*goalchemysdk.Contract
+}
+
+// New{{.Type}} binds the {{.Type}} contract deployed at address to client.
+func New{{.Type}}(client *goalchemysdk.AlchemyClient, address types.Address) *{{.Type}} {
+	return &{{.Type}}{goalchemysdk.NewContract(client, address, {{lower .Type}}Abi)}
+}
+{{if .Bin}}
+// {{.Type}}DeployData returns the data of a transaction deploying {{.Type}}:
+// its bytecode followed by the constructor arguments.
+func {{.Type}}DeployData({{with .Constructor}}{{params .Inputs}}{{end}}) (types.Data, error) {
+	bin := types.MustParseData({{.Type}}Bin)
+{{- with .Constructor}}
+	args, err := {{lower $.Type}}Abi.Constructor.Pack({{args .Inputs}})
+	if err != nil {
+		return nil, err
+	}
+	return append(bin, args...), nil
+{{- else}}
+	return bin, nil
+{{- end}}
+}
+{{end}}
+{{- range .Structs}}
+// {{.Name}} {{.Doc}}.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} {{tag .}}
+{{- end}}
+}
+{{end}}
+{{- range .Calls}}
+// {{.GoName}} calls {{.Signature}} at block blk, nil for the latest one.
+func (t *{{$.Type}}) {{.GoName}}(ctx context.Context, blk goalchemysdk.BlockRef{{if .Inputs}}, {{params .Inputs}}{{end}}) ({{if .OutputType}}{{.OutputType}}, {{end}}error) {
+{{- if .OutputType}}
+	values, err := t.Contract.CallAt(ctx, blk, "{{.Signature}}"{{if .Inputs}}, {{args .Inputs}}{{end}})
+	if err != nil {
+		return {{.Zero}}, err
+	}
+	m, err := t.ABI.Method("{{.Signature}}")
+	if err != nil {
+		return {{.Zero}}, err
+	}
+	var out {{.OutputType}}
+	err = m.Outputs.Copy(&out, values)
+	return out, err
+{{- else}}
+	_, err := t.Contract.CallAt(ctx, blk, "{{.Signature}}"{{if .Inputs}}, {{args .Inputs}}{{end}})
+	return err
+{{- end}}
+}
+{{end}}
+{{- range .Transacts}}
+// Pack{{.GoName}} returns the data of a transaction to the contract calling
+// {{.Signature}}.
+func (t *{{$.Type}}) Pack{{.GoName}}({{params .Inputs}}) (types.Data, error) {
+	payload, err := t.ABI.Pack("{{.Signature}}"{{if .Inputs}}, {{args .Inputs}}{{end}})
+	return payload, err
+}
+{{end}}
+{{- range .Events}}
+// {{$.Type}}{{.GoName}} is a {{.Signature}} event.
+type {{$.Type}}{{.GoName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} {{tag .}}
+{{- end}}
+	Raw goalchemysdk.LogsResult ` + "`abi:\"-\"`" + `
+}
+
+// {{$.Type}}{{.GoName}}Iterator iterates over {{.GoName}} events, see
+// Filter{{.GoName}}.
+type {{$.Type}}{{.GoName}}Iterator struct {
+	Event  *{{$.Type}}{{.GoName}} // the current event
+	events []goalchemysdk.ContractEvent
+	err    error
+}
+
+// Next moves to the next event, it returns false once done or when an
+// event cannot be decoded, see Error.
+func (it *{{$.Type}}{{.GoName}}Iterator) Next() bool {
+	if it.err != nil || len(it.events) == 0 {
+		return false
+	}
+	e := it.events[0]
+	it.events = it.events[1:]
+	event := &{{$.Type}}{{.GoName}}{Raw: e.Log}
+	if it.err = e.Copy(event); it.err != nil {
+		return false
+	}
+	it.Event = event
+	return true
+}
+
+// Error returns the error that stopped the iteration.
+func (it *{{$.Type}}{{.GoName}}Iterator) Error() error {
+	return it.err
+}
+
+// Filter{{.GoName}} returns the {{.GoName}} events logged between blocks
+// fromBlock and toBlock, nil for the node defaults.{{if .Filters}} Indexed inputs
+// given nil match any value.{{end}}
+func (t *{{$.Type}}) Filter{{.GoName}}(ctx context.Context, fromBlock goalchemysdk.BlockRef, toBlock goalchemysdk.BlockRef{{if .Filters}}, {{params .Filters}}{{end}}) (*{{$.Type}}{{.GoName}}Iterator, error) {
+	events, err := t.Contract.Filter(ctx, "{{.Signature}}", fromBlock, toBlock{{if .Topics}}, {{join .Topics ", "}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+	return &{{$.Type}}{{.GoName}}Iterator{events: events}, nil
+}
+{{end}}`))
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	token, err := os.ReadFile("testdata/Token.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		opts    bindOptions
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "artifact",
+			opts: bindOptions{Package: "token", Type: "Token", Abi: token},
+			want: []string{
+				"const TokenBin = \"0x6080604052348015600e575f80fd5b50\"",
+				"func TokenDeployData(name string, decimals uint8) (types.Data, error)",
+				"func (t *Token) BalanceOf(ctx context.Context, blk goalchemysdk.BlockRef, owner types.Address) (*big.Int, error)",
+				"func (t *Token) GetReserves(ctx context.Context, blk goalchemysdk.BlockRef) (TokenGetReservesOutput, error)",
+				// promoted Contract.Call is not shadowed
+				"func (t *Token) Call0(ctx context.Context, blk goalchemysdk.BlockRef, arg0 [32]byte) error",
+				"func (t *Token) PackTransfer0(to types.Address, amount *big.Int, data []byte) (types.Data, error)",
+				"func (t *Token) PackSwap(orders []TokenOrder, arg1 [32]byte) (types.Data, error)",
+				"func (t *Token) FilterTransfer(ctx context.Context, fromBlock goalchemysdk.BlockRef, toBlock goalchemysdk.BlockRef, from *types.Address, to *types.Address) (*TokenTransferIterator, error)",
+				"func (t *Token) FilterNamed(ctx context.Context, fromBlock goalchemysdk.BlockRef, toBlock goalchemysdk.BlockRef, name *string) (*TokenNamedIterator, error)",
+				"Name  [32]byte                `abi:\"name\"`",
+				"Pair  [32]byte                `abi:\"pair\"`",
+				"func (t *Token) FilterMinted(ctx context.Context, fromBlock goalchemysdk.BlockRef, toBlock goalchemysdk.BlockRef, id *big.Int, tag []byte, nonce *uint64) (*TokenMintedIterator, error)",
+				"func (t *Token) FilterPaired(ctx context.Context, fromBlock goalchemysdk.BlockRef, toBlock goalchemysdk.BlockRef, owner *types.Address) (*TokenPairedIterator, error)",
+			},
+		},
+		{
+			name:    "abi without bytecode",
+			opts:    bindOptions{Package: "pair", Type: "Pair", Abi: []byte(`[{"type":"constructor","inputs":[]},{"type":"function","name":"token0","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]}]`)},
+			want:    []string{"func (t *Pair) Token0(ctx context.Context, blk goalchemysdk.BlockRef) (types.Address, error)"},
+			notWant: []string{"PairBin", "PairDeployData"},
+		},
+		{
+			name: "bytecode given",
+			opts: bindOptions{Package: "pair", Type: "Pair", Abi: []byte(`[]`), Bin: "6080"},
+			want: []string{"const PairBin = \"0x6080\"", "func PairDeployData() (types.Data, error)"},
+		},
+		{name: "unexported type", opts: bindOptions{Package: "token", Type: "token", Abi: token}, wantErr: "invalid type name"},
+		{name: "invalid package", opts: bindOptions{Package: "my-token", Type: "Token", Abi: token}, wantErr: "invalid package name"},
+		{name: "invalid json", opts: bindOptions{Package: "token", Type: "Token", Abi: []byte(`[{`)}, wantErr: "invalid abi json"},
+		{name: "artifact without abi", opts: bindOptions{Package: "token", Type: "Token", Abi: []byte(`{"bytecode":"0x"}`)}, wantErr: "artifact without abi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := generate(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("generate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(src), want) {
+					t.Errorf("generate() missing %s in\n%s", want, src)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(src), notWant) {
+					t.Errorf("generate() unexpected %s in\n%s", notWant, src)
+				}
+			}
+		})
+	}
+}
+
+func TestReadArtifact(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantAbi string
+		wantBin string
+	}{
+		{"abi", "[\n  {\"type\": \"fallback\"}\n]", `[{"type":"fallback"}]`, ""},
+		{"hardhat", `{"abi": [], "bytecode": "0x6080"}`, `[]`, "0x6080"},
+		{"foundry", `{"abi": [], "bytecode": {"object": "0x6080", "linkReferences": {}}}`, `[]`, "0x6080"},
+		{"interface", `{"abi": [], "bytecode": "0x"}`, `[]`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAbi, gotBin, err := readArtifact([]byte(tt.data))
+			if err != nil || string(gotAbi) != tt.wantAbi || gotBin != tt.wantBin {
+				t.Errorf("readArtifact() = %s, %q, %v, want %s, %q", gotAbi, gotBin, err, tt.wantAbi, tt.wantBin)
+			}
+		})
+	}
+}
+
+// TestGenerate_compiles builds and tests the binding of testdata/Token.json
+// with testdata/token_test.go, in a package of the module.
+func TestGenerate_compiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a package")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	token, err := os.ReadFile("testdata/Token.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(bindOptions{Package: "token", Type: "Token", Abi: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage, err := os.ReadFile("testdata/token_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := os.MkdirTemp(".", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := os.WriteFile(filepath.Join(dir, "token.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "token_test.go"), usage, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"vet", "./" + dir}, {"test", "./" + dir}} {
+		out, err := exec.Command(goTool, args...).CombinedOutput()
+		if err != nil {
+			t.Errorf("go %s: %v\n%s\n%s", strings.Join(args, " "), err, out, src)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Command alchemy-abigen generates type-safe Go bindings of a contract from
+// its ABI, calling it through the go-alchemy-sdk client:
+//
+//	alchemy-abigen -abi Token.json -pkg token -out token.go
+//
+// The ABI is a json ABI or a hardhat or foundry artifact, whose bytecode is
+// used unless -bin is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	abiPath := flag.String("abi", "", "json ABI or artifact of the contract, - for stdin")
+	binPath := flag.String("bin", "", "file of the hex encoded creation bytecode, optional")
+	pkg := flag.String("pkg", "", "package of the generated code")
+	typ := flag.String("type", "", "Go type of the contract, defaults to the ABI file name")
+	out := flag.String("out", "", "file written, defaults to stdout")
+	flag.Parse()
+
+	if err := run(*abiPath, *binPath, *pkg, *typ, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "alchemy-abigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath, binPath, pkg, typ, out string) error {
+	if abiPath == "" || pkg == "" {
+		flag.Usage()
+		return fmt.Errorf("-abi and -pkg are required")
+	}
+	var opts bindOptions
+	var err error
+	if abiPath == "-" {
+		opts.Abi, err = io.ReadAll(os.Stdin)
+	} else {
+		opts.Abi, err = os.ReadFile(abiPath)
+	}
+	if err != nil {
+		return err
+	}
+	if binPath != "" {
+		bin, err := os.ReadFile(binPath)
+		if err != nil {
+			return err
+		}
+		opts.Bin = strings.TrimSpace(string(bin))
+	}
+	opts.Package, opts.Type = pkg, typ
+	if opts.Type == "" {
+		if abiPath == "-" {
+			return fmt.Errorf("-type is required reading the ABI from stdin")
+		}
+		// Token.json and Token.abi.json give Token
+		name, _, _ := strings.Cut(filepath.Base(abiPath), ".")
+		opts.Type = identifier(name)
+	}
+
+	src, err := generate(opts)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
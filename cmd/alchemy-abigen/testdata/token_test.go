@@ -0,0 +1,125 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goalchemysdk "github.com/nabetse00/go-alchemy-sdk"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// exercises the binding generated from Token.json, see TestGenerate_compiles
+func TestToken(t *testing.T) {
+	owner := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	var sent, answer string
+	c := &goalchemysdk.AlchemyClient{
+		Transport: goalchemysdk.TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(answer), err
+		}),
+	}
+	token := NewToken(c, types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c"))
+	ctx := context.Background()
+
+	answer = `"0x00000000000000000000000000000000000000000000000000000000000003e8"`
+	balance, err := token.BalanceOf(ctx, goalchemysdk.LATEST, owner)
+	if err != nil || balance.Int64() != 1000 {
+		t.Fatalf("BalanceOf() = %v, %v", balance, err)
+	}
+	if !strings.Contains(sent, `"data":"0x70a082310000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed"`) {
+		t.Errorf("BalanceOf() sent %s", sent)
+	}
+
+	answer = `"0x00000000000000000000000000000000000000000000000000000000000003e800000000000000000000000000000000000000000000000000000000000007d00000000000000000000000000000000000000000000000000000000065538600"`
+	reserves, err := token.GetReserves(ctx, nil)
+	if err != nil || reserves.Reserve1.Int64() != 2000 || reserves.BlockTimestampLast != 0x65538600 {
+		t.Errorf("GetReserves() = %+v, %v", reserves, err)
+	}
+
+	answer = `"0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed00000000000000000000000000000000000000000000000000000000000003e8"`
+	order, err := token.Order(ctx, nil, reserves.Reserve0)
+	if err != nil || order.TokenIn != owner || order.AmountIn.Int64() != 1000 {
+		t.Errorf("Order() = %+v, %v", order, err)
+	}
+
+	data, err := token.PackTransfer(owner, reserves.Reserve0)
+	if err != nil || !strings.HasPrefix(data.String(), "0xa9059cbb") {
+		t.Errorf("PackTransfer() = %s, %v", data, err)
+	}
+	data, err = token.PackSwap([]TokenOrder{order}, [32]byte{1})
+	if err != nil || len(data) != 4+5*32 {
+		t.Errorf("PackSwap() = %s, %v", data, err)
+	}
+	deploy, err := TokenDeployData("Token", 18)
+	if err != nil || !strings.HasPrefix(deploy.String(), TokenBin) || len(deploy) != len(types.MustParseData(TokenBin))+4*32 {
+		t.Errorf("TokenDeployData() = %s, %v", deploy, err)
+	}
+
+	answer = `[{
+		"address":"0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
+		"topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef","0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed","0x000000000000000000000000000000000000000000000000000000000000dead"],
+		"data":"0x00000000000000000000000000000000000000000000000000000000000003e8",
+		"blockNumber":"0x10","transactionHash":"0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb","blockHash":"0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb","logIndex":"0x2"
+	}]`
+	it, err := token.FilterTransfer(ctx, goalchemysdk.BlockNumber(16), nil, nil, &owner)
+	if err != nil {
+		t.Fatalf("FilterTransfer() error = %v", err)
+	}
+	want := `"topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",null,"0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed"]`
+	if !strings.Contains(sent, want) {
+		t.Errorf("FilterTransfer() sent %s, want %s", sent, want)
+	}
+	var transfers []*TokenTransfer
+	for it.Next() {
+		transfers = append(transfers, it.Event)
+	}
+	if it.Error() != nil || len(transfers) != 1 || transfers[0].From != owner || transfers[0].Value.Int64() != 1000 || transfers[0].Raw.LogIndex.Uint64() != 2 {
+		t.Errorf("TokenTransferIterator = %+v, %v", transfers, it.Error())
+	}
+
+	// a Transfer of another contract, an ERC-721 one
+	answer = strings.Replace(answer, `"data":"0x00000000000000000000000000000000000000000000000000000000000003e8"`, `"data":"0x"`, 1)
+	if _, err := token.FilterTransfer(ctx, nil, nil, nil, nil); err == nil {
+		t.Errorf("FilterTransfer() undecodable log error = nil")
+	}
+
+	answer = `[]`
+	name := "Token"
+	if _, err := token.FilterNamed(ctx, nil, nil, &name); err != nil || !strings.Contains(sent, `"topics":["0x`) || strings.Count(sent, `"0x`) != 3 {
+		t.Errorf("FilterNamed() = %v, sent %s", err, sent)
+	}
+
+	// indexed ints are filtered on through pointers, bytes through slices
+	minted, _ := tokenAbi.Event("Minted")
+	nonce := uint64(7)
+	if _, err := token.FilterMinted(ctx, nil, nil, nil, []byte("tag"), &nonce); err != nil {
+		t.Errorf("FilterMinted() error = %v", err)
+	}
+	// keccak256("tag")
+	tagHash := "0x97721080eade4b057eae589a1435045aca04c882598f794915100d4634a2c909"
+	if want := `"topics":["` + minted.Topic.Hex() + `",null,"` + tagHash + `","0x0000000000000000000000000000000000000000000000000000000000000007"]`; !strings.Contains(sent, want) {
+		t.Errorf("FilterMinted() sent %s, want %s", sent, want)
+	}
+
+	// an indexed static array is logged as its hash
+	paired, _ := tokenAbi.Event("Paired")
+	pairHash := types.MustParseHash("0x9c0257114eb9399a2985f8e75dad7600c5d89fe3824ffa99ec1c3eb8bf3b0501")
+	logs, _ := json.Marshal([]goalchemysdk.LogsResult{{
+		Topics: []types.Hash{paired.Topic, pairHash, types.BytesToHash(owner.Bytes())},
+		Data:   types.MustParseData("0x0000000000000000000000000000000000000000000000000000000000000005"),
+	}})
+	answer = string(logs)
+	pairs, err := token.FilterPaired(ctx, nil, nil, &owner)
+	if err != nil {
+		t.Fatalf("FilterPaired() error = %v", err)
+	}
+	if !pairs.Next() || pairs.Event.Pair != pairHash || pairs.Event.Owner != owner || pairs.Event.At != 5 {
+		t.Errorf("TokenPairedIterator = %+v, %v", pairs.Event, pairs.Error())
+	}
+	if want := `"topics":["` + paired.Topic.Hex() + `",null,"0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed"]`; !strings.Contains(sent, want) {
+		t.Errorf("FilterPaired() sent %s, want %s", sent, want)
+	}
+}
@@ -0,0 +1,130 @@
+package goalchemysdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// Contract is a contract deployed at Address, called through its ABI.
+type Contract struct {
+	Address types.Address
+	ABI     *abi.ABI
+	client  *AlchemyClient
+	errors  CustomErrors
+}
+
+// ContractEvent is a log of a contract event with its inputs decoded.
+type ContractEvent struct {
+	Name  string
+	Args  []interface{} // one per event input, see abi.Event.Unpack
+	Log   LogsResult
+	event abi.Event
+}
+
+// NewContract binds the contract at address, whose interface is
+// contractAbi, to the client.
+func NewContract(client *AlchemyClient, address types.Address, contractAbi *abi.ABI) *Contract {
+	return &Contract{Address: address, ABI: contractAbi, client: client, errors: CustomErrorsOf(contractAbi)}
+}
+
+// Call calls the function method, a name or a signature when overloaded,
+// with args at the latest block and returns its decoded outputs, see
+// abi.Arguments.Pack and abi.Arguments.Unpack. A revert is returned as a
+// *RevertError, with the errors of the contract ABI decoded.
+func (ct *Contract) Call(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	return ct.CallAt(ctx, LATEST, method, args...)
+}
+
+// CallAt is Call against the state of block blk.
+func (ct *Contract) CallAt(ctx context.Context, blk BlockRef, method string, args ...interface{}) ([]interface{}, error) {
+	m, output, err := ct.call(ctx, blk, method, args)
+	if err != nil {
+		return nil, err
+	}
+	return m.Unpack(output)
+}
+
+// CallInto is Call decoding the outputs into out, see
+// abi.Arguments.UnpackInto: a pointer to the only output, or to a struct
+// of the outputs.
+func (ct *Contract) CallInto(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	m, output, err := ct.call(ctx, LATEST, method, args)
+	if err != nil {
+		return err
+	}
+	return m.UnpackInto(out, output)
+}
+
+func (ct *Contract) call(ctx context.Context, blk BlockRef, method string, args []interface{}) (abi.Method, []byte, error) {
+	m, err := ct.ABI.Method(method)
+	if err != nil {
+		return abi.Method{}, nil, &AlchemyClientError{"Contract.Call", err.Error()}
+	}
+	data, err := m.Pack(args...)
+	if err != nil {
+		return abi.Method{}, nil, &AlchemyClientError{"Contract.Call", err.Error()}
+	}
+	resp, err := ct.client.Eth_call(ctx, CallTxn{To: &ct.Address, Data: data}, blk)
+	if err != nil {
+		return abi.Method{}, nil, ct.revertError(err)
+	}
+	if len(resp.Result) == 0 && len(m.Outputs) > 0 {
+		// calls to accounts without code succeed with no output
+		return abi.Method{}, nil, &AlchemyClientError{"Contract.Call", fmt.Sprintf("empty output of %s, is there a contract at %s?", m.Signature, ct.Address)}
+	}
+	return m, resp.Result, nil
+}
+
+// revertError decodes the errors of the contract ABI the client does not
+// know of.
+func (ct *Contract) revertError(err error) error {
+	var rev *RevertError
+	if !errors.As(err, &rev) || rev.Kind != REVERT_UNKNOWN || rev.Data == "" {
+		return err
+	}
+	decoded, decodeErr := DecodeRevert(rev.Data, ct.errors)
+	if decodeErr != nil || decoded.Kind == REVERT_UNKNOWN {
+		return err
+	}
+	decoded.Err = rev.Err
+	return decoded
+}
+
+// Filter returns the logs of the event name, or signature when
+// overloaded, emitted by the contract between blocks from and to, nil for
+// the node defaults. indexed filters the indexed inputs in order, nil
+// matching any value, see abi.Event.Topics.
+func (ct *Contract) Filter(ctx context.Context, event string, from BlockRef, to BlockRef, indexed ...interface{}) ([]ContractEvent, error) {
+	ev, err := ct.ABI.Event(event)
+	if err != nil {
+		return nil, &AlchemyClientError{"Contract.Filter", err.Error()}
+	}
+	topics, err := ev.Topics(indexed...)
+	if err != nil {
+		return nil, &AlchemyClientError{"Contract.Filter", err.Error()}
+	}
+	address := ct.Address
+	resp, err := ct.client.Eth_getLogs(ctx, []LogsParam{{Address: &address, FromBlock: from, ToBlock: to, Topics: topics}})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]ContractEvent, 0, len(resp.Result))
+	for _, log := range resp.Result {
+		decoded, err := DecodeLog(log, ev)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *decoded)
+	}
+	return events, nil
+}
+
+// Copy decodes the event into out, a pointer to a struct whose fields are
+// matched with the event inputs by name, see abi.Event.UnpackInto.
+func (e *ContractEvent) Copy(out interface{}) error {
+	return e.event.UnpackInto(out, e.Log.Topics, e.Log.Data)
+}
@@ -0,0 +1,141 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+var testTokenAbi = abi.MustParseHuman(
+	"function balanceOf(address owner) view returns (uint256)",
+	"function getReserves() view returns (uint112 reserve0, uint112 reserve1, uint32 blockTimestampLast)",
+	"event Transfer(address indexed from, address indexed to, uint256 value)",
+	"error ERC20InsufficientBalance(address sender, uint256 balance, uint256 needed)",
+)
+
+func TestContract_Call(t *testing.T) {
+	token := types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c")
+	owner := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	var sent string
+	var answer string
+	var apiErr *AlchemyApiError
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			return json.RawMessage(answer), err
+		}),
+	}
+	ct := NewContract(c, token, testTokenAbi)
+	ctx := context.Background()
+
+	answer = `"0x00000000000000000000000000000000000000000000000000000000000003e8"`
+	got, err := ct.Call(ctx, "balanceOf", owner)
+	if err != nil || got[0].(*big.Int).Int64() != 1000 {
+		t.Fatalf("Contract.Call() = %v, %v", got, err)
+	}
+	want := `eth_call[{"to":"0x2CDE9919e81b20B4B33DD562a48a84b54C48F00C","data":"0x70a082310000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},"latest"]`
+	if sent != want {
+		t.Errorf("Contract.Call() sent %s, want %s", sent, want)
+	}
+	if _, err := ct.CallAt(ctx, BlockNumber(17), "balanceOf", owner); err != nil || !strings.HasSuffix(sent, `,"0x11"]`) {
+		t.Errorf("Contract.CallAt() = %v, sent %s", err, sent)
+	}
+
+	answer = `"0x00000000000000000000000000000000000000000000000000000000000003e800000000000000000000000000000000000000000000000000000000000007d00000000000000000000000000000000000000000000000000000000065538600"`
+	var reserves struct {
+		Reserve0           *big.Int
+		Reserve1           *big.Int
+		BlockTimestampLast uint32
+	}
+	if err := ct.CallInto(ctx, &reserves, "getReserves"); err != nil || reserves.Reserve1.Int64() != 2000 || reserves.BlockTimestampLast != 0x65538600 {
+		t.Errorf("Contract.CallInto() = %+v, %v", reserves, err)
+	}
+
+	invalid := []struct {
+		name    string
+		method  string
+		args    []interface{}
+		answer  string
+		wantErr string
+	}{
+		{"unknown method", "approve", nil, `"0x"`, "no function approve"},
+		{"bad argument", "balanceOf", []interface{}{"owner"}, `"0x"`, "argument owner"},
+		{"no contract", "balanceOf", []interface{}{owner}, `"0x"`, "empty output"},
+		{"short output", "balanceOf", []interface{}{owner}, `"0x01"`, "balanceOf(address) output"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			answer = tt.answer
+			if _, err := ct.Call(ctx, tt.method, tt.args...); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Contract.Call() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// the client does not know the errors of the contract
+	apiErr = &AlchemyApiError{Code: CODE_EXECUTION_REVERTED, Message: "execution reverted", Data: ErrorData(`"` + revertCustom + `"`)}
+	_, err = ct.Call(ctx, "balanceOf", owner)
+	var rev *RevertError
+	if !errors.As(err, &rev) || rev.Kind != REVERT_CUSTOM || rev.Name != "ERC20InsufficientBalance" || rev.Err != apiErr {
+		t.Errorf("Contract.Call() error = %v, want ERC20InsufficientBalance", err)
+	}
+}
+
+func TestContract_Filter(t *testing.T) {
+	token := types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c")
+	from := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	var sent string
+	var answer string
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(answer), err
+		}),
+	}
+	ct := NewContract(c, token, testTokenAbi)
+	answer = `[{
+		"address":"0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
+		"topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef","0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed","0x000000000000000000000000000000000000000000000000000000000000dead"],
+		"data":"0x00000000000000000000000000000000000000000000000000000000000003e8",
+		"blockNumber":"0x10","transactionHash":"0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb","blockHash":"0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb","logIndex":"0x2"
+	}]`
+	events, err := ct.Filter(context.Background(), "Transfer", BlockNumber(16), LATEST, from)
+	if err != nil {
+		t.Fatalf("Contract.Filter() error = %v", err)
+	}
+	want := `eth_getLogs[{"address":"0x2CDE9919e81b20B4B33DD562a48a84b54C48F00C","fromBlock":"0x10","toBlock":"latest","topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef","0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed"]}]`
+	if sent != want {
+		t.Errorf("Contract.Filter() sent %s, want %s", sent, want)
+	}
+	if len(events) != 1 || events[0].Name != "Transfer" || events[0].Args[0] != from || events[0].Log.LogIndex.Uint64() != 2 {
+		t.Fatalf("Contract.Filter() = %+v", events)
+	}
+	var transfer struct {
+		From  types.Address
+		To    types.Address
+		Value *big.Int
+	}
+	if err := events[0].Copy(&transfer); err != nil || transfer.To != types.MustParseAddress("0x000000000000000000000000000000000000dead") || transfer.Value.Int64() != 1000 {
+		t.Errorf("ContractEvent.Copy() = %+v, %v", transfer, err)
+	}
+
+	// a log of another event with the same topic, an ERC-721 Transfer
+	answer = strings.Replace(answer, `"data":"0x00000000000000000000000000000000000000000000000000000000000003e8"`, `"data":"0x"`, 1)
+	if _, err := ct.Filter(context.Background(), "Transfer", nil, nil); err == nil || !strings.Contains(err.Error(), "log 2 of transaction") {
+		t.Errorf("Contract.Filter() error = %v, want undecodable log", err)
+	}
+	if _, err := ct.Filter(context.Background(), "Approval", nil, nil); err == nil {
+		t.Errorf("Contract.Filter() unknown event error = nil")
+	}
+}
@@ -1,11 +1,14 @@
 package goalchemysdk
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	//"sync"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
 )
 
 type ProxyResult struct {
@@ -13,57 +16,62 @@ type ProxyResult struct {
 	err     error
 }
 
-type ProxyDetectorFunc func(*AlchemyClient, string, BlockTag, chan ProxyResult)
+type ProxyDetectorFunc func(context.Context, *AlchemyClient, types.Address, BlockRef, chan ProxyResult)
 
-func createJob(jobCounter *uint, detector ProxyDetectorFunc, c *AlchemyClient, addr string, bt BlockTag, out chan ProxyResult) {
+func createJob(ctx context.Context, jobCounter *uint, detector ProxyDetectorFunc, c *AlchemyClient, addr types.Address, bt BlockRef, out chan ProxyResult) {
 	*jobCounter++
-	go detector(c, addr, bt, out)
+	go detector(ctx, c, addr, bt, out)
 }
 
-func (c *AlchemyClient) DetectProxyTarget(proxyAddress string, blockTag BlockTag) (address string, err error) {
-	if blockTag == "" {
+// DetectProxyTarget runs every known proxy detector concurrently and returns
+// the first implementation address found. Pending detectors are cancelled
+// once a result is available or ctx is done.
+func (c *AlchemyClient) DetectProxyTarget(ctx context.Context, proxyAddress types.Address, blockTag BlockRef) (address types.Address, err error) {
+	if blockTag == nil {
 		blockTag = LATEST
 	}
-	address = "0x"
-	err = errors.New("no Proxy detected")
-
-	res := make(chan ProxyResult)
-	done := make(chan bool)
-	jobs := uint(0)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	detectors := []ProxyDetectorFunc{checkEIP1167, checkEIP1967Direct,
 		checkEIP1967Beacon, checkOpenZeppelin, checkEIP1822,
 		checkEIP897, checkGnosisSafe, checkComptroller}
 
+	// buffered so detectors never block once the collector has returned
+	res := make(chan ProxyResult, len(detectors))
+	done := make(chan ProxyResult, 1)
+	jobs := uint(0)
+
 	for _, f := range detectors {
-		createJob(&jobs, f, c, proxyAddress, blockTag, res)
+		createJob(ctx, &jobs, f, c, proxyAddress, blockTag, res)
 	}
 
 	// exit on valid result routine
-	go func(res chan ProxyResult, done chan bool) {
+	go func(res chan ProxyResult, done chan ProxyResult) {
 		counter := uint(0)
 		for {
 			val := <-res
 			counter++
 			if val.err == nil {
-				address = val.address
-				err = val.err
-				done <- true
+				done <- val
 				break
 			}
 			if counter >= jobs {
-				address = "0x"
-				err = errors.New("no proxy found")
-				done <- true
+				done <- ProxyResult{address: "0x", err: errors.New("no proxy found")}
 				break
 			}
 		}
 	}(res, done)
 
-	// fmt.Println("waiting for done!!!")
-	<-done
-	// fmt.Println("returned !!!")
-	return address, err
+	select {
+	case val := <-done:
+		if val.err != nil {
+			return types.Address{}, val.err
+		}
+		return types.ParseAddress(val.address)
+	case <-ctx.Done():
+		return types.Address{}, ctx.Err()
+	}
 }
 
 func readAddress(address string) (string, error) {
@@ -85,9 +93,8 @@ func readAddress(address string) (string, error) {
 }
 
 // storage based detection
-func checkWithStorage(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult, slot string) {
-	resp, err := c.Eth_getStorageAt(proxyAddress, slot, blockTag)
-	
+func checkWithStorage(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult, slot string) {
+	slotHash, err := types.ParseHash(slot)
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -95,7 +102,16 @@ func checkWithStorage(c *AlchemyClient, proxyAddress string, blockTag BlockTag,
 		}
 		return
 	}
-	address, err := readAddress(resp.Result)
+	resp, err := c.Eth_getStorageAt(ctx, proxyAddress, slotHash, blockTag)
+
+	if err != nil {
+		res <- ProxyResult{
+			address: "0x",
+			err:     err,
+		}
+		return
+	}
+	address, err := readAddress(resp.Result.Hex())
 
 	if err != nil {
 		res <- ProxyResult{
@@ -112,18 +128,18 @@ func checkWithStorage(c *AlchemyClient, proxyAddress string, blockTag BlockTag,
 }
 
 // OpenZeppelin proxy pattern
-func checkOpenZeppelin(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	checkWithStorage(c, proxyAddress, blockTag, res, OPEN_ZEPPELIN_IMPLEMENTATION_SLOT)
+func checkOpenZeppelin(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	checkWithStorage(ctx, c, proxyAddress, blockTag, res, OPEN_ZEPPELIN_IMPLEMENTATION_SLOT)
 }
 
 // EIP-1822 Universal Upgradeable Proxy Standard
-func checkEIP1822(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	checkWithStorage(c, proxyAddress, blockTag, res, EIP_1822_LOGIC_SLOT)
+func checkEIP1822(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	checkWithStorage(ctx, c, proxyAddress, blockTag, res, EIP_1822_LOGIC_SLOT)
 }
 
 // EIP-897 DelegateProxy pattern
-func checkEIP897(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	address, err := getAddressFromBeacon(c, proxyAddress, EIP_897_INTERFACE[0])
+func checkEIP897(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	address, err := getAddressFromBeacon(ctx, c, proxyAddress, EIP_897_INTERFACE[0])
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -138,8 +154,8 @@ func checkEIP897(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res c
 }
 
 // GnosisSafeProxy contract
-func checkGnosisSafe(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	address, err := getAddressFromBeacon(c, proxyAddress, GNOSIS_SAFE_PROXY_INTERFACE[0])
+func checkGnosisSafe(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	address, err := getAddressFromBeacon(ctx, c, proxyAddress, GNOSIS_SAFE_PROXY_INTERFACE[0])
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -154,8 +170,8 @@ func checkGnosisSafe(c *AlchemyClient, proxyAddress string, blockTag BlockTag, r
 }
 
 // Comptroller proxy
-func checkComptroller(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	address, err := getAddressFromBeacon(c, proxyAddress, COMPTROLLER_PROXY_INTERFACE[0])
+func checkComptroller(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	address, err := getAddressFromBeacon(ctx, c, proxyAddress, COMPTROLLER_PROXY_INTERFACE[0])
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -170,13 +186,13 @@ func checkComptroller(c *AlchemyClient, proxyAddress string, blockTag BlockTag,
 }
 
 // EIP-1967 direct proxy
-func checkEIP1967Direct(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	checkWithStorage(c, proxyAddress, blockTag, res, EIP_1967_LOGIC_SLOT)
+func checkEIP1967Direct(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	checkWithStorage(ctx, c, proxyAddress, blockTag, res, EIP_1967_LOGIC_SLOT)
 }
 
 // EIP-1967 beacon proxy
-func checkEIP1967Beacon(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	resp, err := c.Eth_getStorageAt(proxyAddress, EIP_1967_BEACON_SLOT, blockTag)
+func checkEIP1967Beacon(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	resp, err := c.Eth_getStorageAt(ctx, proxyAddress, types.MustParseHash(EIP_1967_BEACON_SLOT), blockTag)
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -184,7 +200,11 @@ func checkEIP1967Beacon(c *AlchemyClient, proxyAddress string, blockTag BlockTag
 		}
 		return
 	}
-	beaconAddress, err := readAddress(resp.Result)
+	var beaconAddress types.Address
+	beaconHex, err := readAddress(resp.Result.Hex())
+	if err == nil {
+		beaconAddress, err = types.ParseAddress(beaconHex)
+	}
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -193,10 +213,10 @@ func checkEIP1967Beacon(c *AlchemyClient, proxyAddress string, blockTag BlockTag
 		return
 	}
 
-	address, err := getAddressFromBeacon(c, beaconAddress, EIP_1167_BEACON_METHODS[0])
+	address, err := getAddressFromBeacon(ctx, c, beaconAddress, EIP_1167_BEACON_METHODS[0])
 
 	if err != nil {
-		address, err = getAddressFromBeacon(c, beaconAddress, EIP_1167_BEACON_METHODS[1])
+		address, err = getAddressFromBeacon(ctx, c, beaconAddress, EIP_1167_BEACON_METHODS[1])
 		if err != nil {
 			res <- ProxyResult{
 				address: "0x",
@@ -217,20 +237,24 @@ func checkEIP1967Beacon(c *AlchemyClient, proxyAddress string, blockTag BlockTag
 	}
 }
 
-func getAddressFromBeacon(c *AlchemyClient, proxyAddress string, methodEncoded string) (string, error) {
-	resp, err := c.Eth_call(CallTxn{To: proxyAddress, Data: methodEncoded}, LATEST)
+func getAddressFromBeacon(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, methodEncoded string) (string, error) {
+	data, err := types.ParseData(methodEncoded)
+	if err != nil {
+		return "0x", err
+	}
+	resp, err := c.Eth_call(ctx, CallTxn{To: &proxyAddress, Data: data}, LATEST)
 	if err != nil {
 		return "0x", err
 	}
-	address, err := readAddress(resp.Result)
+	address, err := readAddress(resp.Result.Hex())
 	if err != nil {
 		return "0x", err
 	}
 	return address, nil
 }
 
-func checkEIP1167(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res chan ProxyResult) {
-	resp, err := c.Eth_getCode(proxyAddress, blockTag)
+func checkEIP1167(ctx context.Context, c *AlchemyClient, proxyAddress types.Address, blockTag BlockRef, res chan ProxyResult) {
+	resp, err := c.Eth_getCode(ctx, proxyAddress, blockTag)
 	if err != nil {
 		res <- ProxyResult{
 			address: "0x",
@@ -238,7 +262,7 @@ func checkEIP1167(c *AlchemyClient, proxyAddress string, blockTag BlockTag, res
 		}
 		return
 	}
-	addr, err := parse1167Bytecode(resp.Result)
+	addr, err := parse1167Bytecode(resp.Result.Hex())
 
 	if err != nil {
 		res <- ProxyResult{
@@ -1,10 +1,11 @@
 package goalchemysdk
 
 import (
+	"context"
 	"errors"
-	"net/http"
 	"testing"
-	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
 )
 
 func Test_parse1167Bytecode(t *testing.T) {
@@ -86,7 +87,7 @@ func Test_parse1167Bytecode(t *testing.T) {
 }
 
 func TestDetectProxyTarget(t *testing.T) {
-	initEnvs()
+	c := &AlchemyClient{Transport: testNode}
 	type args struct {
 		c            *AlchemyClient
 		proxyAddress string
@@ -95,252 +96,172 @@ func TestDetectProxyTarget(t *testing.T) {
 	tests := []struct {
 		name        string
 		args        args
-		wantAddress string
+		wantAddress types.Address
 		wantErr     bool
 	}{
 
 		{
 			name: "test no proxy",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0xdead3fd06D57D140f6ad8C2913DbE87fdecDd5F",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x",
+			wantAddress: types.Address{},
+			wantErr:     true,
+		},
+		{
+			name: "test not a proxy",
+			args: args{
+				c:            c,
+				proxyAddress: "0xdeadbeefbd5d07dd0cecc66161fc93d7c9000da1",
+				blockTag:     LATEST,
+			},
+			wantAddress: types.Address{},
 			wantErr:     true,
 		},
 		{
 			name: "test detect proxy EIP1167",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0xa81043fd06D57D140f6ad8C2913DbE87fdecDd5F",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x0000000010fd301be3200e67978e3cc67c962f48",
+			wantAddress: types.MustParseAddress("0x0000000010fd301be3200e67978e3cc67c962f48"),
 			wantErr:     false,
 		},
 		{
 			name: "test detect proxy EIP1967 Direct Proxy",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0xA7AeFeaD2F25972D80516628417ac46b3F2604Af",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x4bd844f72a8edd323056130a86fc624d0dbcf5b0",
+			wantAddress: types.MustParseAddress("0x4bd844f72a8edd323056130a86fc624d0dbcf5b0"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects EIP1967 beacon proxies",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0xDd4e2eb37268B047f55fC5cAf22837F9EC08A881",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0xe5c048792dcf2e4a56000c8b6a47f21df22752d1",
+			wantAddress: types.MustParseAddress("0xe5c048792dcf2e4a56000c8b6a47f21df22752d1"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects EIP1967 beacon variant proxies",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x114f1388fAB456c4bA31B1850b244Eedcd024136",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x36b799160cdc2d9809d108224d1967cc9b7d321c",
+			wantAddress: types.MustParseAddress("0x36b799160cdc2d9809d108224d1967cc9b7d321c"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects OpenZeppelin proxies",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x8260b9eC6d472a34AD081297794d7Cc00181360a",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0xe4e4003afe3765aca8149a82fc064c0b125b9e5a",
+			wantAddress: types.MustParseAddress("0xe4e4003afe3765aca8149a82fc064c0b125b9e5a"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects EIP-897 delegate proxies",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x8260b9eC6d472a34AD081297794d7Cc00181360a",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0xe4e4003afe3765aca8149a82fc064c0b125b9e5a",
+			wantAddress: types.MustParseAddress("0xe4e4003afe3765aca8149a82fc064c0b125b9e5a"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects EIP-1167 minimal proxies",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x6d5d9b6ec51c15f45bfa4c460502403351d5b999",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x210ff9ced719e9bf2444dbc3670bac99342126fa",
+			wantAddress: types.MustParseAddress("0x210ff9ced719e9bf2444dbc3670bac99342126fa"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects EIP-1167 minimal proxies with vanity addresses",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0xa81043fd06D57D140f6ad8C2913DbE87fdecDd5F",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x0000000010fd301be3200e67978e3cc67c962f48",
+			wantAddress: types.MustParseAddress("0x0000000010fd301be3200e67978e3cc67c962f48"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects Gnosis Safe proxies",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x0DA0C3e52C977Ed3cBc641fF02DD271c3ED55aFe",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0xd9db270c1b5e3bd161e8c8503c55ceabee709552",
+			wantAddress: types.MustParseAddress("0xd9db270c1b5e3bd161e8c8503c55ceabee709552"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects Compound's custom proxy",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x3d9819210A31b4961b30EF54bE2aeD79B9c9Cd3B",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0xbafe01ff935c7305907c33bf824352ee5979b526",
+			wantAddress: types.MustParseAddress("0xbafe01ff935c7305907c33bf824352ee5979b526"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects ARB token proxy [EIP1967]",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ARB_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x912ce59144191c1204e64559fe8253a0e49e6548",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0xc4ed0a9ea70d5bcc69f748547650d32cc219d882",
+			wantAddress: types.MustParseAddress("0xc4ed0a9ea70d5bcc69f748547650d32cc219d882"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects Abracadabra [GnosisSafeProxy]",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ARB_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0xfBDf75866904767dE1Caa8B64eb18a7562517F5A",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x3e5c63644e683549055b9be8653de26e0b4cd36e",
+			wantAddress: types.MustParseAddress("0x3e5c63644e683549055b9be8653de26e0b4cd36e"),
 			wantErr:     false,
 		},
 		{
 			name: "test detects MUX [GnosisSafeProxy]",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ARB_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress: "0x4Fa610DD115e790B8768A482Fc366803534e9Adc",
 				blockTag:     LATEST,
 			},
-			wantAddress: "0x3e5c63644e683549055b9be8653de26e0b4cd36e",
+			wantAddress: types.MustParseAddress("0x3e5c63644e683549055b9be8653de26e0b4cd36e"),
 			wantErr:     false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotAddress, err := tt.args.c.DetectProxyTarget(tt.args.proxyAddress, tt.args.blockTag)
+			proxyAddress, err := types.ParseAddress(tt.args.proxyAddress)
+			if err != nil {
+				// a malformed address fails before any request
+				if !tt.wantErr {
+					t.Errorf("types.ParseAddress() error = %v", err)
+				}
+				return
+			}
+			gotAddress, err := tt.args.c.DetectProxyTarget(context.Background(), proxyAddress, tt.args.blockTag)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DetectProxyTarget() error = %v, wantErr %v, got %v", err, tt.wantErr, gotAddress)
 				return
@@ -353,7 +274,8 @@ func TestDetectProxyTarget(t *testing.T) {
 }
 
 func Test_checkWithStorage(t *testing.T) {
-	initEnvs()
+	// every request fails
+	failing := &AlchemyClient{Transport: fakeTransport(nil)}
 	type args struct {
 		c            *AlchemyClient
 		proxyAddress string
@@ -367,16 +289,9 @@ func Test_checkWithStorage(t *testing.T) {
 		want ProxyResult
 	}{
 		{
-			name: "test wrong api error",
+			name: "test api error",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  "wrong",
-					MaxRetry: 3,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: failing,
 				proxyAddress: "0x4Fa610DD115e790B8768A482Fc366803534e9Adc",
 				res:          make(chan ProxyResult),
 				slot:         "FAKE_SLOT",
@@ -390,7 +305,7 @@ func Test_checkWithStorage(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			go checkWithStorage(tt.args.c, tt.args.proxyAddress, tt.args.blockTag, tt.args.res, tt.args.slot)
+			go checkWithStorage(context.Background(), tt.args.c, types.MustParseAddress(tt.args.proxyAddress), tt.args.blockTag, tt.args.res, tt.args.slot)
 			result := <-tt.args.res
 
 			if result.address != tt.want.address {
@@ -405,7 +320,8 @@ func Test_checkWithStorage(t *testing.T) {
 }
 
 func Test_checkEIP1167(t *testing.T) {
-	initEnvs()
+	// every request fails
+	failing := &AlchemyClient{Transport: fakeTransport(nil)}
 	type args struct {
 		c            *AlchemyClient
 		proxyAddress string
@@ -418,16 +334,9 @@ func Test_checkEIP1167(t *testing.T) {
 		want ProxyResult
 	}{
 		{
-			name: "test wrong api error",
+			name: "test api error",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  "wrong",
-					MaxRetry: 3,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: failing,
 				proxyAddress: "0x4Fa610DD115e790B8768A482Fc366803534e9Adc",
 				res:          make(chan ProxyResult),
 				blockTag:     LATEST,
@@ -440,7 +349,7 @@ func Test_checkEIP1167(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			go checkEIP1167(tt.args.c, tt.args.proxyAddress, tt.args.blockTag, tt.args.res)
+			go checkEIP1167(context.Background(), tt.args.c, types.MustParseAddress(tt.args.proxyAddress), tt.args.blockTag, tt.args.res)
 			result := <-tt.args.res
 
 			if result.address != tt.want.address {
@@ -455,7 +364,10 @@ func Test_checkEIP1167(t *testing.T) {
 }
 
 func Test_checkEIP1967Beacon(t *testing.T) {
-	initEnvs()
+	// every request fails
+	failing := &AlchemyClient{Transport: fakeTransport(nil)}
+	c := &AlchemyClient{Transport: testNode}
+	defer func(methods []string) { EIP_1167_BEACON_METHODS = methods }(EIP_1167_BEACON_METHODS)
 	type args struct {
 		c             *AlchemyClient
 		proxyAddress  string
@@ -469,16 +381,9 @@ func Test_checkEIP1967Beacon(t *testing.T) {
 		want ProxyResult
 	}{
 		{
-			name: "test wrong api error",
+			name: "test api error",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  "wrong",
-					MaxRetry: 3,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: failing,
 				proxyAddress:  "0x4Fa610DD115e790B8768A482Fc366803534e9Adc",
 				res:           make(chan ProxyResult),
 				beaconMethods: EIP_1167_BEACON_METHODS,
@@ -492,14 +397,7 @@ func Test_checkEIP1967Beacon(t *testing.T) {
 		{
 			name: "test alt method errrors",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress:  "0x114f1388fAB456c4bA31B1850b244Eedcd024136",
 				res:           make(chan ProxyResult),
 				blockTag:      LATEST,
@@ -513,14 +411,7 @@ func Test_checkEIP1967Beacon(t *testing.T) {
 		{
 			name: "test alt method errrors",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress:  "0x114f1388fAB456c4bA31B1850b244Eedcd024136",
 				res:           make(chan ProxyResult),
 				blockTag:      LATEST,
@@ -535,14 +426,7 @@ func Test_checkEIP1967Beacon(t *testing.T) {
 		{
 			name: "test alt method errrors",
 			args: args{
-				c: &AlchemyClient{
-					ApiKey:   ALCHEMY_API_KEY_TEST,
-					Network:  ETH_MAINNET,
-					MaxRetry: 10,
-					netClient: &http.Client{
-						Timeout: time.Second *10 ,
-					},
-				},
+				c: c,
 				proxyAddress:  "0x114f1388fAB456c4bA31B1850b244Eedcd024136",
 				res:           make(chan ProxyResult),
 				blockTag:      LATEST,
@@ -559,7 +443,7 @@ func Test_checkEIP1967Beacon(t *testing.T) {
 			//EIP_1167_BEACON_METHODS[1] = EIP_1167_BEACON_METHODS[0]
 			//EIP_1167_BEACON_METHODS[0] = "wrong"
 			EIP_1167_BEACON_METHODS = tt.args.beaconMethods
-			go checkEIP1967Beacon(tt.args.c, tt.args.proxyAddress, tt.args.blockTag, tt.args.res)
+			go checkEIP1967Beacon(context.Background(), tt.args.c, types.MustParseAddress(tt.args.proxyAddress), tt.args.blockTag, tt.args.res)
 			result := <-tt.args.res
 
 			if result.address != tt.want.address {
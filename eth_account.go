@@ -0,0 +1,65 @@
+package goalchemysdk
+
+import (
+	"context"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// AccountProof is the eth_getProof answer: an account and some of its
+// storage slots, with the Merkle proofs binding them to a state root.
+type AccountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []types.Data    `json:"accountProof"`
+	Balance      *types.Quantity `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        *types.Quantity `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []StorageProof  `json:"storageProof"`
+}
+
+// StorageProof is the Merkle proof of a storage slot value against the
+// storage root of its account.
+type StorageProof struct {
+	Key   *types.Quantity `json:"key"`
+	Value *types.Quantity `json:"value"`
+	Proof []types.Data    `json:"proof"`
+}
+
+// Eth_getBalance returns the balance of address in wei.
+func (c *AlchemyClient) Eth_getBalance(ctx context.Context, address types.Address, blocktag BlockRef) (*AlchemyResponse[*types.Quantity], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_getBalance",
+		Params:  []interface{}{address, blockParam(blocktag)},
+	}
+	return executePost[interface{}, *types.Quantity](ctx, c, j)
+}
+
+// Eth_getTransactionCount returns the number of transactions sent from
+// address, the nonce of its next transaction.
+func (c *AlchemyClient) Eth_getTransactionCount(ctx context.Context, address types.Address, blocktag BlockRef) (*AlchemyResponse[*types.Quantity], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_getTransactionCount",
+		Params:  []interface{}{address, blockParam(blocktag)},
+	}
+	return executePost[interface{}, *types.Quantity](ctx, c, j)
+}
+
+// Eth_getProof returns the account at address and its storage slots keys,
+// with their Merkle proofs. See AccountProof.Verify.
+func (c *AlchemyClient) Eth_getProof(ctx context.Context, address types.Address, keys []types.Hash, blocktag BlockRef) (*AlchemyResponse[AccountProof], error) {
+	if keys == nil {
+		keys = []types.Hash{}
+	}
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_getProof",
+		Params:  []interface{}{address, keys, blockParam(blocktag)},
+	}
+	return executePost[interface{}, AccountProof](ctx, c, j)
+}
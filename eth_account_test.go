@@ -0,0 +1,66 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+func TestAlchemyClient_Eth_account(t *testing.T) {
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	sent := ""
+	answer := ""
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(answer), err
+		}),
+	}
+	ctx := context.Background()
+
+	answer = `"0x4563918244f40000"`
+	balance, err := c.Eth_getBalance(ctx, address, FINALIZED)
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_getBalance() error = %v", err)
+	}
+	if balance.Result.Uint64() != 5e18 || sent != `eth_getBalance["`+address.Hex()+`","finalized"]` {
+		t.Errorf("AlchemyClient.Eth_getBalance() = %v, sent %s", balance.Result, sent)
+	}
+
+	answer = `"0x3"`
+	nonce, err := c.Eth_getTransactionCount(ctx, address, Number(16))
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_getTransactionCount() error = %v", err)
+	}
+	if nonce.Result.Uint64() != 3 || sent != `eth_getTransactionCount["`+address.Hex()+`","0x10"]` {
+		t.Errorf("AlchemyClient.Eth_getTransactionCount() = %v, sent %s", nonce.Result, sent)
+	}
+
+	answer = `{
+		"address":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"accountProof":["0xc0"],
+		"balance":"0x0",
+		"codeHash":"` + EMPTY_CODE_HASH.Hex() + `",
+		"nonce":"0x0",
+		"storageHash":"` + EMPTY_ROOT_HASH.Hex() + `",
+		"storageProof":[{"key":"0x0000000000000000000000000000000000000000000000000000000000000001","value":"0x0","proof":[]}]
+	}`
+	proof, err := c.Eth_getProof(ctx, address, []types.Hash{types.BytesToHash([]byte{1})}, nil)
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_getProof() error = %v", err)
+	}
+	if sent != `eth_getProof["`+address.Hex()+`",["0x0000000000000000000000000000000000000000000000000000000000000001"],"latest"]` {
+		t.Errorf("AlchemyClient.Eth_getProof() sent %s", sent)
+	}
+	p := proof.Result
+	if p.Address != address || len(p.AccountProof) != 1 || len(p.StorageProof) != 1 || p.StorageProof[0].Key.Uint64() != 1 {
+		t.Errorf("AlchemyClient.Eth_getProof() = %+v", p)
+	}
+
+	if _, err := c.Eth_getProof(ctx, address, nil, LATEST); err != nil || sent != `eth_getProof["`+address.Hex()+`",[],"latest"]` {
+		t.Errorf("AlchemyClient.Eth_getProof() without keys sent %s, error = %v", sent, err)
+	}
+}
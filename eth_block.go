@@ -0,0 +1,110 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// Block is a block as returned by eth_getBlockByNumber and
+// eth_getBlockByHash.
+type Block struct {
+	BlockHeader
+	Size            *types.Quantity   `json:"size,omitempty"`
+	TotalDifficulty *types.Quantity   `json:"totalDifficulty,omitempty"`
+	Uncles          []types.Hash      `json:"uncles"`
+	Transactions    BlockTransactions `json:"transactions"`
+	Withdrawals     []Withdrawal      `json:"withdrawals,omitempty"`
+}
+
+// Withdrawal is a validator withdrawal (EIP-4895), Amount is in gwei.
+type Withdrawal struct {
+	Index          *types.Quantity `json:"index"`
+	ValidatorIndex *types.Quantity `json:"validatorIndex"`
+	Address        types.Address   `json:"address"`
+	Amount         *types.Quantity `json:"amount"`
+}
+
+// BlockTransactions holds the transactions of a block. Hashes is always
+// set, Full only when the block was requested with full transactions.
+type BlockTransactions struct {
+	Hashes []types.Hash
+	Full   []TransactionJson
+}
+
+func (bt BlockTransactions) MarshalJSON() ([]byte, error) {
+	if bt.Full != nil {
+		return json.Marshal(bt.Full)
+	}
+	if bt.Hashes == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(bt.Hashes)
+}
+
+func (bt *BlockTransactions) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*bt = BlockTransactions{Hashes: make([]types.Hash, len(raw))}
+	if len(raw) == 0 || bytes.HasPrefix(bytes.TrimSpace(raw[0]), []byte(`"`)) {
+		for i, r := range raw {
+			if err := json.Unmarshal(r, &bt.Hashes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	bt.Full = make([]TransactionJson, len(raw))
+	for i, r := range raw {
+		if err := json.Unmarshal(r, &bt.Full[i]); err != nil {
+			return err
+		}
+		bt.Hashes[i] = bt.Full[i].Hash
+	}
+	return nil
+}
+
+// Eth_blockNumber returns the number of the most recent block.
+func (c *AlchemyClient) Eth_blockNumber(ctx context.Context) (*AlchemyResponse[BlockNumber], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_blockNumber",
+		Params:  []interface{}{},
+	}
+	return executePost[interface{}, BlockNumber](ctx, c, j)
+}
+
+// Eth_getBlockByNumber returns the block at blk, a tag or a number, with
+// its full transactions when full is set and only their hashes otherwise.
+// The result is nil when the block is unknown.
+func (c *AlchemyClient) Eth_getBlockByNumber(ctx context.Context, blk BlockRef, full bool) (*AlchemyResponse[*Block], error) {
+	ref, err := blockNumberParam("Eth_getBlockByNumber", blk)
+	if err != nil {
+		return &AlchemyResponse[*Block]{}, err
+	}
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_getBlockByNumber",
+		Params:  []interface{}{ref, full},
+	}
+	return executePost[interface{}, *Block](ctx, c, j)
+}
+
+// Eth_getBlockByHash returns the block with the given hash, with its full
+// transactions when full is set and only their hashes otherwise.
+// The result is nil when the block is unknown.
+func (c *AlchemyClient) Eth_getBlockByHash(ctx context.Context, hash types.Hash, full bool) (*AlchemyResponse[*Block], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_getBlockByHash",
+		Params:  []interface{}{hash, full},
+	}
+	return executePost[interface{}, *Block](ctx, c, j)
+}
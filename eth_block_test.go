@@ -0,0 +1,165 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+const (
+	testBlockHashes = `{
+		"number":"0x112a880",
+		"hash":"0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2",
+		"parentHash":"0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb",
+		"miner":"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+		"baseFeePerGas":"0x3b9aca00",
+		"blobGasUsed":"0x20000",
+		"excessBlobGas":"0x0",
+		"uncles":[],
+		"transactions":["0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3"],
+		"withdrawals":[{"index":"0x1","validatorIndex":"0x2","address":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed","amount":"0x3"}]
+	}`
+	testBlockFull = `{
+		"number":"0x112a880",
+		"hash":"0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2",
+		"uncles":[],
+		"transactions":[{"hash":"0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3","from":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed","nonce":"0x7"}]
+	}`
+)
+
+func TestBlockTransactions_JSON(t *testing.T) {
+	txHash := types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3")
+	tests := []struct {
+		name     string
+		data     string
+		want     BlockTransactions
+		wantJson string
+		wantErr  bool
+	}{
+		{
+			name:     "hashes",
+			data:     `["` + txHash.Hex() + `"]`,
+			want:     BlockTransactions{Hashes: []types.Hash{txHash}},
+			wantJson: `["` + txHash.Hex() + `"]`,
+		},
+		{
+			name: "full",
+			data: `[{"hash":"` + txHash.Hex() + `","from":"0x0000000000000000000000000000000000000001"}]`,
+			want: BlockTransactions{
+				Hashes: []types.Hash{txHash},
+				Full:   []TransactionJson{{Hash: txHash, From: types.MustParseAddress("0x0000000000000000000000000000000000000001")}},
+			},
+			wantJson: `[{"hash":"` + txHash.Hex() + `","from":"0x0000000000000000000000000000000000000001"}]`,
+		},
+		{
+			name:     "empty",
+			data:     `[]`,
+			want:     BlockTransactions{Hashes: []types.Hash{}},
+			wantJson: `[]`,
+		},
+		{
+			name:    "invalid hash",
+			data:    `["0x1234"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got BlockTransactions
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BlockTransactions.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BlockTransactions.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("BlockTransactions.MarshalJSON() error = %v", err)
+			}
+			if string(b) != tt.wantJson {
+				t.Errorf("BlockTransactions.MarshalJSON() = %s, want %s", b, tt.wantJson)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_Eth_blocks(t *testing.T) {
+	blockHash := types.MustParseHash("0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2")
+	txHash := types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3")
+	sent := ""
+	answer := ""
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(answer), err
+		}),
+	}
+	ctx := context.Background()
+
+	answer = `"0x112a880"`
+	number, err := c.Eth_blockNumber(ctx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_blockNumber() error = %v", err)
+	}
+	if number.Result != 18000000 || sent != `eth_blockNumber[]` {
+		t.Errorf("AlchemyClient.Eth_blockNumber() = %v, sent %s", number.Result, sent)
+	}
+
+	answer = testBlockHashes
+	resp, err := c.Eth_getBlockByNumber(ctx, number.Result, false)
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_getBlockByNumber() error = %v", err)
+	}
+	if sent != `eth_getBlockByNumber["0x112a880",false]` {
+		t.Errorf("AlchemyClient.Eth_getBlockByNumber() sent %s", sent)
+	}
+	block := resp.Result
+	if block == nil || block.Hash != blockHash || block.Number.Uint64() != 18000000 {
+		t.Fatalf("AlchemyClient.Eth_getBlockByNumber() = %+v", block)
+	}
+	if block.BaseFeePerGas.Uint64() != 1e9 || block.BlobGasUsed.Uint64() != 0x20000 || block.ExcessBlobGas.Uint64() != 0 {
+		t.Errorf("AlchemyClient.Eth_getBlockByNumber() fees = %v %v %v", block.BaseFeePerGas, block.BlobGasUsed, block.ExcessBlobGas)
+	}
+	if !reflect.DeepEqual(block.Transactions, BlockTransactions{Hashes: []types.Hash{txHash}}) {
+		t.Errorf("AlchemyClient.Eth_getBlockByNumber() transactions = %v", block.Transactions)
+	}
+	wantWithdrawals := []Withdrawal{{
+		Index:          types.Uint64Quantity(1),
+		ValidatorIndex: types.Uint64Quantity(2),
+		Address:        types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"),
+		Amount:         types.Uint64Quantity(3),
+	}}
+	if !reflect.DeepEqual(block.Withdrawals, wantWithdrawals) {
+		t.Errorf("AlchemyClient.Eth_getBlockByNumber() withdrawals = %v, want %v", block.Withdrawals, wantWithdrawals)
+	}
+
+	answer = testBlockFull
+	resp, err = c.Eth_getBlockByHash(ctx, blockHash, true)
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_getBlockByHash() error = %v", err)
+	}
+	if sent != `eth_getBlockByHash["`+blockHash.Hex()+`",true]` {
+		t.Errorf("AlchemyClient.Eth_getBlockByHash() sent %s", sent)
+	}
+	if txs := resp.Result.Transactions; len(txs.Full) != 1 || txs.Full[0].Nonce.Uint64() != 7 || txs.Hashes[0] != txHash {
+		t.Errorf("AlchemyClient.Eth_getBlockByHash() transactions = %+v", txs)
+	}
+
+	answer = `null`
+	resp, err = c.Eth_getBlockByNumber(ctx, FINALIZED, true)
+	if err != nil || resp.Result != nil {
+		t.Errorf("AlchemyClient.Eth_getBlockByNumber() unknown block = %v, %v, want nil", resp.Result, err)
+	}
+
+	if _, err := c.Eth_getBlockByNumber(ctx, Hash(blockHash, false), false); err == nil {
+		t.Errorf("AlchemyClient.Eth_getBlockByNumber() by hash, error = nil")
+	}
+}
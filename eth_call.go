@@ -1,5 +1,12 @@
 package goalchemysdk
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
 // getCode Params
 // String - 20 Bytes - Address
 // String - Either the hex value of a block number OR a block hash OR One of the following block tags:
@@ -7,27 +14,91 @@ package goalchemysdk
 // latest - The most recent block in the canonical chain observed by the client, this block may be re-orged out of the canonical chain even under healthy/normal conditions.
 // earliest - The lowest numbered block the client has available. Intuitively, you can think of this as the first block created.
 type CallTxn struct{
-	From string `json:"from,omitempty"`
-	To string `json:"to"`
-	Data string `json:"data"`
-	Gas string `json:"gas,omitempty"`
-	GasPrice string `json:"gasPrice,omitempty"`
-	Value string `json:"value,omitempty"`
-	BlockHash string `json:"blockHash,omitempty"`
-	RequireCanonical bool `json:"requireCanonical,omitempty"`
+	From *types.Address `json:"from,omitempty"`
+	To *types.Address `json:"to,omitempty"` // nil runs Data as contract creation code
+	Data types.Data `json:"data"`
+	Gas *types.Quantity `json:"gas,omitempty"`
+	GasPrice *types.Quantity `json:"gasPrice,omitempty"`
+	Value *types.Quantity `json:"value,omitempty"`
+}
+
+// CallBlk is the block the call runs against, latest when nil. A block
+// hash is given with Hash.
+type CallBlk = BlockRef
+
+// AccountOverride replaces parts of an account state for the duration of
+// a call. State replaces the whole storage, StateDiff only the given slots,
+// they cannot be used together.
+type AccountOverride struct {
+	Balance   *types.Quantity           `json:"balance,omitempty"`
+	Nonce     *types.Quantity           `json:"nonce,omitempty"`
+	Code      types.Data                `json:"code,omitempty"`
+	State     map[types.Hash]types.Hash `json:"state,omitempty"`
+	StateDiff map[types.Hash]types.Hash `json:"stateDiff,omitempty"`
 }
 
-type CallBlk = BlockTag
+// StateOverride maps addresses to their overridden state.
+type StateOverride map[types.Address]AccountOverride
+
+// merge returns a overridden by the fields set in b. A State of b replaces
+// the one of a, the slots of a StateDiff are added to those of a.
+func (a AccountOverride) merge(b AccountOverride) AccountOverride {
+	if b.Balance != nil {
+		a.Balance = b.Balance
+	}
+	if b.Nonce != nil {
+		a.Nonce = b.Nonce
+	}
+	if len(b.Code) > 0 {
+		a.Code = b.Code
+	}
+	if b.State != nil {
+		a.State = b.State
+	}
+	if b.StateDiff != nil {
+		diff := make(map[types.Hash]types.Hash, len(a.StateDiff)+len(b.StateDiff))
+		for slot, value := range a.StateDiff {
+			diff[slot] = value
+		}
+		for slot, value := range b.StateDiff {
+			diff[slot] = value
+		}
+		a.StateDiff = diff
+	}
+	return a
+}
 
+type CallResult = types.Data
 
-type CallResult = string
+// Eth_call executes txn against the state of blk without creating a
+// transaction. An optional state override simulates the call on a
+// modified state. Many overrides are merged in order, the fields set by a
+// later override of an address replacing those of earlier ones.
+func (c *AlchemyClient) Eth_call(ctx context.Context, txn CallTxn, blk CallBlk, overrides ...StateOverride) (*AlchemyResponse[CallResult], error) {
+	override := StateOverride{}
+	for _, o := range overrides {
+		for address, account := range o {
+			override[address] = override[address].merge(account)
+		}
+	}
+	for address, account := range override {
+		if account.State != nil && account.StateDiff != nil {
+			return &AlchemyResponse[CallResult]{}, &AlchemyClientError{"eth_call", fmt.Sprintf("state and stateDiff both overridden for %s", address)}
+		}
+	}
 
-func (c *AlchemyClient) Eth_call(txn CallTxn,  blk CallBlk) (*AlchemyResponse[CallResult], error) {
-	j := JsonParams[CallTxn]{
+	params := []interface{}{txn, blockParam(blk)}
+	if len(override) > 0 {
+		params = append(params, override)
+	}
+	j := JsonParams[interface{}]{
 		Id:      1,
 		Jsonrpc: "2.0",
 		Method:  "eth_call",
-		Params:  []CallTxn{txn},
+		Params:  params,
 	}
-	return executePost[CallTxn, CallResult](c, j)
+	resp, err := executePost[interface{}, CallResult](ctx, c, j)
+	// a revert comes back as a *RevertError with its reason decoded
+	return resp, c.revertError(err)
 }
+
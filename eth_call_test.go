@@ -1,14 +1,18 @@
 package goalchemysdk
 
 import (
-	"net/http"
+	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
-	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
 )
 
 func TestAlchemyClient_Eth_call(t *testing.T) {
-	initEnvs()
+	c := &AlchemyClient{Transport: testNode}
+	resolver := types.MustParseAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41")
+	deadbeef := types.MustParseAddress("0xdeadbeefbd5d07dd0cecc66161fc93d7c9000da1")
 	type args struct {
 		txn CallTxn
 		blk CallBlk
@@ -22,18 +26,12 @@ func TestAlchemyClient_Eth_call(t *testing.T) {
 	}{
 		{
 			name: "test raw eth call - address result",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ETH_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: c,
 			args: args{
 				txn: CallTxn{
 					//From:             "",
-					To:   "0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41",
-					Data: "0x3b3b57debf074faa138b72c65adbdcfb329847e4f2c04bde7f7dd7fcad5a52d2f395a558",
+					To:   &resolver,
+					Data: types.MustParseData("0x3b3b57debf074faa138b72c65adbdcfb329847e4f2c04bde7f7dd7fcad5a52d2f395a558"),
 					// addr(0xbf074faa138b72c65adbdcfb329847e4f2c04bde7f7dd7fcad5a52d2f395a558)
 					// 0x5555763613a12D8F3e73be831DFf8598089d3dCa => ricmoo.eth
 					// Gas:              "0x00",
@@ -47,24 +45,18 @@ func TestAlchemyClient_Eth_call(t *testing.T) {
 			want: &AlchemyResponse[CallResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
-				Result:  "0x0000000000000000000000005555763613a12d8f3e73be831dff8598089d3dca",
+				Result:  types.MustParseData("0x0000000000000000000000005555763613a12d8f3e73be831dff8598089d3dca"),
 			},
 			wantErr: false,
 		},
 		{
 			name: "test raw eth call - not result",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET, 
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: c,
 			args: args{
 				txn: CallTxn{
 					//From:             "",
-					To:   "0xdeadbeefBd5D07dd0CeCc66161FC93D7c9000da1",
-					Data: "0x70a082310000000000000000000000006E0d01A76C3Cf4288372a29124A26D4353EE51BE",
+					To:   &deadbeef,
+					Data: types.MustParseData("0x70a082310000000000000000000000006E0d01A76C3Cf4288372a29124A26D4353EE51BE"),
 					// balanceOf()
 					// Gas:              "0x00",
 					// GasPrice:         "0x09184e72a000",
@@ -77,14 +69,14 @@ func TestAlchemyClient_Eth_call(t *testing.T) {
 			want: &AlchemyResponse[CallResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
-				Result:  "0x",
+				Result:  types.MustParseData("0x"),
 			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.c.Eth_call(tt.args.txn, tt.args.blk)
+			got, err := tt.c.Eth_call(context.Background(), tt.args.txn, tt.args.blk)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.Eth_call() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -95,3 +87,106 @@ func TestAlchemyClient_Eth_call(t *testing.T) {
 		})
 	}
 }
+
+func TestAlchemyClient_Eth_call_Params(t *testing.T) {
+	to := types.MustParseAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41")
+	txn := CallTxn{To: &to, Data: types.MustParseData("0x3b3b57de")}
+	account := types.MustParseAddress("0x5555763613a12d8f3e73be831dff8598089d3dca")
+	override := StateOverride{
+		account: {Balance: types.MustParseQuantity("0xde0b6b3a7640000"), StateDiff: map[types.Hash]types.Hash{{}: types.BytesToHash([]byte{1})}},
+	}
+	tests := []struct {
+		name      string
+		txn       CallTxn
+		blk       CallBlk
+		overrides []StateOverride
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "tag",
+			txn:  txn,
+			blk:  SAFE,
+			want: `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},"safe"]`,
+		},
+		{
+			name: "default latest",
+			txn:  txn,
+			want: `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},"latest"]`,
+		},
+		{
+			name: "hex number",
+			txn:  txn,
+			blk:  Number(0x10d4f),
+			want: `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},"0x10d4f"]`,
+		},
+		{
+			name: "hash as block",
+			txn:  txn,
+			blk:  BlockTag("0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"),
+			want: `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},{"blockHash":"0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"}]`,
+		},
+		{
+			name: "eip-1898 hash",
+			txn:  txn,
+			blk:  Hash(types.MustParseHash("0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"), true),
+			want: `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},{"blockHash":"0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2","requireCanonical":true}]`,
+		},
+		{
+			name: "contract creation",
+			txn:  CallTxn{Data: types.MustParseData("0x6080")},
+			want: `[{"data":"0x6080"},"latest"]`,
+		},
+		{
+			name:      "state override",
+			txn:       txn,
+			blk:       LATEST,
+			overrides: []StateOverride{override},
+			want:      `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},"latest",{"0x5555763613a12D8F3e73be831DFf8598089d3dCa":{"balance":"0xde0b6b3a7640000","stateDiff":{"0x0000000000000000000000000000000000000000000000000000000000000000":"0x0000000000000000000000000000000000000000000000000000000000000001"}}}]`,
+		},
+		{
+			name: "overrides of one address merged",
+			txn:  txn,
+			blk:  LATEST,
+			overrides: []StateOverride{
+				override,
+				{account: {Nonce: types.Uint64Quantity(7), StateDiff: map[types.Hash]types.Hash{types.BytesToHash([]byte{1}): types.BytesToHash([]byte{2})}}},
+				{account: {Balance: types.MustParseQuantity("0x1")}},
+			},
+			want: `[{"to":"0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41","data":"0x3b3b57de"},"latest",{"0x5555763613a12D8F3e73be831DFf8598089d3dCa":{"balance":"0x1","nonce":"0x7","stateDiff":{"0x0000000000000000000000000000000000000000000000000000000000000000":"0x0000000000000000000000000000000000000000000000000000000000000001","0x0000000000000000000000000000000000000000000000000000000000000001":"0x0000000000000000000000000000000000000000000000000000000000000002"}}}]`,
+		},
+		{
+			name:      "state and state diff of merged overrides",
+			txn:       txn,
+			blk:       LATEST,
+			overrides: []StateOverride{override, {account: {State: map[types.Hash]types.Hash{}}}},
+			wantErr:   true,
+		},
+		{
+			name:      "state and state diff",
+			txn:       txn,
+			blk:       LATEST,
+			overrides: []StateOverride{{account: {State: map[types.Hash]types.Hash{}, StateDiff: map[types.Hash]types.Hash{}}}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := ""
+			c := &AlchemyClient{
+				Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+					b, err := json.Marshal(params)
+					sent = string(b)
+					return json.RawMessage(`"0x"`), err
+				}),
+			}
+			_, err := c.Eth_call(context.Background(), tt.txn, tt.blk, tt.overrides...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AlchemyClient.Eth_call() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if sent != tt.want {
+				t.Errorf("AlchemyClient.Eth_call() sent %s, want %s", sent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package goalchemysdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// Reward percentiles and history length used by SuggestFees
+const (
+	FEE_HISTORY_BLOCKS      = 20
+	FEE_PERCENTILE_SLOW     = 10
+	FEE_PERCENTILE_STANDARD = 50
+	FEE_PERCENTILE_FAST     = 90
+	FEE_BASE_FEE_MULTIPLIER = 2 // room for the base fee to rise over a few full blocks
+)
+
+// FeeHistory is the eth_feeHistory answer. BaseFeePerGas and
+// BaseFeePerBlobGas hold one more entry than the blocks asked for: the
+// base fee of the block following the newest one.
+type FeeHistory struct {
+	OldestBlock       *types.Quantity     `json:"oldestBlock"`
+	BaseFeePerGas     []*types.Quantity   `json:"baseFeePerGas"`
+	GasUsedRatio      []float64           `json:"gasUsedRatio"`
+	Reward            [][]*types.Quantity `json:"reward,omitempty"` // per block, one reward per percentile
+	BaseFeePerBlobGas []*types.Quantity   `json:"baseFeePerBlobGas,omitempty"`
+	BlobGasUsedRatio  []float64           `json:"blobGasUsedRatio,omitempty"`
+}
+
+// FeeSuggestion are the EIP-1559 fees of a transaction.
+type FeeSuggestion struct {
+	MaxFeePerGas         *types.Quantity
+	MaxPriorityFeePerGas *types.Quantity
+}
+
+// FeeSuggestions are fees for transactions to be included slowly, in a
+// few blocks, or as fast as possible.
+type FeeSuggestions struct {
+	Slow     FeeSuggestion
+	Standard FeeSuggestion
+	Fast     FeeSuggestion
+}
+
+// Eth_gasPrice returns the gas price of legacy transactions, in wei.
+func (c *AlchemyClient) Eth_gasPrice(ctx context.Context) (*AlchemyResponse[*types.Quantity], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_gasPrice",
+		Params:  []interface{}{},
+	}
+	return executePost[interface{}, *types.Quantity](ctx, c, j)
+}
+
+// Eth_maxPriorityFeePerGas returns a priority fee likely to get a
+// transaction included, in wei.
+func (c *AlchemyClient) Eth_maxPriorityFeePerGas(ctx context.Context) (*AlchemyResponse[*types.Quantity], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_maxPriorityFeePerGas",
+		Params:  []interface{}{},
+	}
+	return executePost[interface{}, *types.Quantity](ctx, c, j)
+}
+
+// Eth_feeHistory returns the base fees and gas usage of blockCount blocks
+// up to newest, with the priority fees paid at each of the percentiles,
+// which go from 0 to 100 in ascending order.
+func (c *AlchemyClient) Eth_feeHistory(ctx context.Context, blockCount uint64, newest BlockRef, percentiles []float64) (*AlchemyResponse[FeeHistory], error) {
+	ref, err := blockNumberParam("Eth_feeHistory", newest)
+	if err != nil {
+		return &AlchemyResponse[FeeHistory]{}, err
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 || (i > 0 && p < percentiles[i-1]) {
+			return &AlchemyResponse[FeeHistory]{}, &AlchemyClientError{"Eth_feeHistory", fmt.Sprintf("invalid reward percentiles %v", percentiles)}
+		}
+	}
+	if percentiles == nil {
+		percentiles = []float64{}
+	}
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_feeHistory",
+		Params:  []interface{}{types.Uint64Quantity(blockCount), ref, percentiles},
+	}
+	return executePost[interface{}, FeeHistory](ctx, c, j)
+}
+
+// Eth_estimateGas returns the gas txn would use if sent now, against blk
+// when given. A revert is returned as a *RevertError.
+func (c *AlchemyClient) Eth_estimateGas(ctx context.Context, txn CallTxn, blk CallBlk) (*AlchemyResponse[*types.Quantity], error) {
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_estimateGas",
+		Params:  []interface{}{txn, blockParam(blk)},
+	}
+	resp, err := executePost[interface{}, *types.Quantity](ctx, c, j)
+	return resp, c.revertError(err)
+}
+
+// SuggestFees reads the fee history of the last FEE_HISTORY_BLOCKS blocks
+// and returns slow, standard and fast fees, see FeeSuggestionsFromHistory.
+func (c *AlchemyClient) SuggestFees(ctx context.Context) (FeeSuggestions, error) {
+	percentiles := []float64{FEE_PERCENTILE_SLOW, FEE_PERCENTILE_STANDARD, FEE_PERCENTILE_FAST}
+	resp, err := c.Eth_feeHistory(ctx, FEE_HISTORY_BLOCKS, LATEST, percentiles)
+	if err != nil {
+		return FeeSuggestions{}, err
+	}
+	return FeeSuggestionsFromHistory(resp.Result)
+}
+
+// FeeSuggestionsFromHistory turns a fee history, queried with the slow,
+// standard and fast reward percentiles in that order, into fees. Each
+// priority fee is the median of the rewards paid at its percentile, each
+// max fee FEE_BASE_FEE_MULTIPLIER times the next base fee plus the
+// priority fee.
+// Chains without priority fees, such as Arbitrum, get zero priority fees.
+func FeeSuggestionsFromHistory(h FeeHistory) (FeeSuggestions, error) {
+	if len(h.BaseFeePerGas) == 0 || h.BaseFeePerGas[len(h.BaseFeePerGas)-1] == nil {
+		return FeeSuggestions{}, &AlchemyClientError{"FeeSuggestionsFromHistory", "fee history without base fee"}
+	}
+	baseFee := new(big.Int).Mul(h.BaseFeePerGas[len(h.BaseFeePerGas)-1].Big(), big.NewInt(FEE_BASE_FEE_MULTIPLIER))
+	suggestions := make([]FeeSuggestion, 3)
+	for i := range suggestions {
+		var rewards []*big.Int
+		for _, block := range h.Reward {
+			if len(block) != len(suggestions) {
+				return FeeSuggestions{}, &AlchemyClientError{"FeeSuggestionsFromHistory", fmt.Sprintf("%d rewards per block, want %d", len(block), len(suggestions))}
+			}
+			if block[i] != nil {
+				rewards = append(rewards, block[i].Big())
+			}
+		}
+		tip := medianBig(rewards)
+		suggestions[i] = FeeSuggestion{
+			MaxFeePerGas:         types.NewQuantity(new(big.Int).Add(baseFee, tip)),
+			MaxPriorityFeePerGas: types.NewQuantity(tip),
+		}
+	}
+	return FeeSuggestions{Slow: suggestions[0], Standard: suggestions[1], Fast: suggestions[2]}, nil
+}
+
+// medianBig returns the median of xs, zero when empty.
+func medianBig(xs []*big.Int) *big.Int {
+	if len(xs) == 0 {
+		return new(big.Int)
+	}
+	sorted := append([]*big.Int(nil), xs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return new(big.Int).Set(sorted[mid])
+	}
+	sum := new(big.Int).Add(sorted[mid-1], sorted[mid])
+	return sum.Rsh(sum, 1)
+}
@@ -0,0 +1,140 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+const testFeeHistory = `{
+	"oldestBlock":"0x10",
+	"baseFeePerGas":["0x64","0x6e","0x78","0x82"],
+	"gasUsedRatio":[0.5,0.9,0.7],
+	"reward":[["0x1","0xa","0x64"],["0x3","0x14","0xc8"],["0x2","0x1e","0x12c"]]
+}`
+
+func TestFeeSuggestionsFromHistory(t *testing.T) {
+	var history FeeHistory
+	if err := json.Unmarshal([]byte(testFeeHistory), &history); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		history FeeHistory
+		want    [3][2]uint64 // max fee and priority fee of slow, standard and fast
+		wantErr bool
+	}{
+		{
+			name:    "history",
+			history: history,
+			want:    [3][2]uint64{{262, 2}, {280, 20}, {460, 200}},
+		},
+		{
+			name:    "no priority fees",
+			history: FeeHistory{BaseFeePerGas: []*types.Quantity{types.Uint64Quantity(10)}},
+			want:    [3][2]uint64{{20, 0}, {20, 0}, {20, 0}},
+		},
+		{
+			name: "even rewards count",
+			history: FeeHistory{
+				BaseFeePerGas: []*types.Quantity{types.Uint64Quantity(10)},
+				Reward: [][]*types.Quantity{
+					{types.Uint64Quantity(1), types.Uint64Quantity(2), types.Uint64Quantity(4)},
+					{types.Uint64Quantity(2), types.Uint64Quantity(5), types.Uint64Quantity(8)},
+				},
+			},
+			want: [3][2]uint64{{21, 1}, {23, 3}, {26, 6}},
+		},
+		{
+			name:    "no base fee",
+			history: FeeHistory{},
+			wantErr: true,
+		},
+		{
+			name: "wrong percentiles count",
+			history: FeeHistory{
+				BaseFeePerGas: []*types.Quantity{types.Uint64Quantity(10)},
+				Reward:        [][]*types.Quantity{{types.Uint64Quantity(1)}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FeeSuggestionsFromHistory(tt.history)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FeeSuggestionsFromHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for i, s := range []FeeSuggestion{got.Slow, got.Standard, got.Fast} {
+				if s.MaxFeePerGas.Uint64() != tt.want[i][0] || s.MaxPriorityFeePerGas.Uint64() != tt.want[i][1] {
+					t.Errorf("FeeSuggestionsFromHistory() preset %d = %v/%v, want %v", i, s.MaxFeePerGas, s.MaxPriorityFeePerGas, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_Eth_gas(t *testing.T) {
+	to := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	sent := ""
+	answer := ""
+	var apiErr error
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			return json.RawMessage(answer), err
+		}),
+	}
+	ctx := context.Background()
+
+	answer = `"0x3b9aca00"`
+	price, err := c.Eth_gasPrice(ctx)
+	if err != nil || price.Result.Uint64() != 1e9 || sent != `eth_gasPrice[]` {
+		t.Errorf("AlchemyClient.Eth_gasPrice() = %v, %v, sent %s", price.Result, err, sent)
+	}
+	tip, err := c.Eth_maxPriorityFeePerGas(ctx)
+	if err != nil || tip.Result.Uint64() != 1e9 || sent != `eth_maxPriorityFeePerGas[]` {
+		t.Errorf("AlchemyClient.Eth_maxPriorityFeePerGas() = %v, %v, sent %s", tip.Result, err, sent)
+	}
+
+	answer = `"0x5208"`
+	gas, err := c.Eth_estimateGas(ctx, CallTxn{To: &to, Value: types.Uint64Quantity(1)}, nil)
+	if err != nil || gas.Result.Uint64() != 21000 {
+		t.Errorf("AlchemyClient.Eth_estimateGas() = %v, %v", gas.Result, err)
+	}
+	if sent != `eth_estimateGas[{"to":"`+to.Hex()+`","data":"0x","value":"0x1"},"latest"]` {
+		t.Errorf("AlchemyClient.Eth_estimateGas() sent %s", sent)
+	}
+
+	answer = testFeeHistory
+	fees, err := c.SuggestFees(ctx)
+	if err != nil || fees.Fast.MaxPriorityFeePerGas.Uint64() != 200 {
+		t.Errorf("AlchemyClient.SuggestFees() = %+v, %v", fees, err)
+	}
+	if sent != `eth_feeHistory["0x14","latest",[10,50,90]]` {
+		t.Errorf("AlchemyClient.SuggestFees() sent %s", sent)
+	}
+	if _, err := c.Eth_feeHistory(ctx, 4, LATEST, []float64{50, 10}); err == nil {
+		t.Errorf("AlchemyClient.Eth_feeHistory() unsorted percentiles, error = nil")
+	}
+	if _, err := c.Eth_feeHistory(ctx, 4, Hash(types.MustParseHash("0x1d59ff54b1eb26b013ce3cb5fc9dab3705b415a67127a003c3e61eb445bb8df2"), false), nil); err == nil {
+		t.Errorf("AlchemyClient.Eth_feeHistory() by hash, error = nil")
+	}
+
+	apiErr = &AlchemyApiError{Code: CODE_EXECUTION_REVERTED, Message: "execution reverted", Data: ErrorData(`"` + revertErrorString + `"`)}
+	_, err = c.Eth_estimateGas(ctx, CallTxn{To: &to}, LATEST)
+	var rev *RevertError
+	if !errors.As(err, &rev) || rev.Reason != "Not enough Ether" {
+		t.Errorf("AlchemyClient.Eth_estimateGas() error = %v, want *RevertError", err)
+	}
+}
@@ -1,15 +1,21 @@
 package goalchemysdk
 
-type GetStorageAtParam = string
+import (
+	"context"
 
-type GetStorageAtResult = string
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
 
-func (c *AlchemyClient) Eth_getStorageAt(address string, id string, blocktag BlockTag) (*AlchemyResponse[GetCodeResult], error) {
-	j := JsonParams[GetCodeParam]{
+type GetStorageAtParam = types.Hash
+
+type GetStorageAtResult = types.Hash
+
+func (c *AlchemyClient) Eth_getStorageAt(ctx context.Context, address types.Address, id GetStorageAtParam, blocktag BlockRef) (*AlchemyResponse[GetStorageAtResult], error) {
+	j := JsonParams[interface{}]{
 		Id:      1,
 		Jsonrpc: "2.0",
 		Method:  "eth_getStorageAt",
-		Params:  []GetCodeParam{address, id, string(blocktag)},
+		Params:  []interface{}{address, id, blockParam(blocktag)},
 	}
-	return executePost[GetStorageAtParam, GetStorageAtResult](c, j)
+	return executePost[interface{}, GetStorageAtResult](ctx, c, j)
 }
\ No newline at end of file
@@ -1,135 +1,114 @@
 package goalchemysdk
 
 import (
-	"net/http"
+	"context"
 	"reflect"
 	"testing"
-	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
 )
 
 func TestAlchemyClient_Eth_getStorageAt(t *testing.T) {
-	initEnvs()
+	c := &AlchemyClient{Transport: testNode}
 	type args struct {
 		address  string
-		id       string
+		id       types.Hash
 		blocktag BlockTag
 	}
 	tests := []struct {
 		name    string
 		c       *AlchemyClient
 		args    args
-		want    *AlchemyResponse[GetCodeResult]
+		want    *AlchemyResponse[GetStorageAtResult]
 		wantErr bool
 	}{
 		{
 			name: "test valid contract",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				address:  "0x912CE59144191C1204E64559FE8253a0e49E6548",
-				id: "0x0",
+				id:       types.Hash{},
 				blocktag: LATEST,
 			},
-			want: &AlchemyResponse[GetCodeResult]{
+			want: &AlchemyResponse[GetStorageAtResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
-				Result:  "0x0000000000000000000000000000000000000000000000000000000000000001",
+				Result:  types.MustParseHash("0x0000000000000000000000000000000000000000000000000000000000000001"),
 			},
 			wantErr: false,
 		},
 		{
 			name: "test invalid address too short",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				address:  "0xdeadbeef",
-				id: "0x0",
+				id:       types.Hash{},
 				blocktag: LATEST,
 			},
-			want: &AlchemyResponse[GetCodeResult]{
+			want: &AlchemyResponse[GetStorageAtResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
 				Error:   ErrorTooShortAddress,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test invalid address too long",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				address:  "0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
-				id: "0x0",
+				id:       types.Hash{},
 				blocktag: LATEST,
 			},
-			want: &AlchemyResponse[GetCodeResult]{
+			want: &AlchemyResponse[GetStorageAtResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
 				Error:   ErrorTooLongAddress,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test invalid address",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				address:  "0xXaedbeefbeef067E90D5Cd1F8052B83562Ae670bA4A211a8",
-				id: "0x0",
+				id:       types.Hash{},
 				blocktag: LATEST,
 			},
-			want: &AlchemyResponse[GetCodeResult]{
+			want: &AlchemyResponse[GetStorageAtResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
 				Error:   ErrorInvalidAddress,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test not a contract",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				address:  "0xdeadbeedeadbeefdeadbeefdeadbeefdeadbeefd",
-				id: "0x0",
+				id:       types.Hash{},
 				blocktag: LATEST,
 			},
-			want: &AlchemyResponse[GetCodeResult]{
+			want: &AlchemyResponse[GetStorageAtResult]{
 				Id:      1,
 				Jsonrpc: "2.0",
-				Result:  "0x0000000000000000000000000000000000000000000000000000000000000000",
+				Result:  types.MustParseHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
 			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.c.Eth_getStorageAt(tt.args.address, tt.args.id, tt.args.blocktag)
+			address, err := types.ParseAddress(tt.args.address)
+			if err != nil {
+				// malformed addresses fail before any request
+				if !tt.wantErr {
+					t.Errorf("types.ParseAddress() error = %v", err)
+				}
+				return
+			}
+			got, err := tt.c.Eth_getStorageAt(context.Background(), address, tt.args.id, tt.args.blocktag)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.Eth_getStorageAt() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1,35 +1,60 @@
 package goalchemysdk
 
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
 // types
 
 type LogsParam struct {
-	BlockHash string   `json:"blockHash,omitempty"`
-	Address   string   `json:"address,omitempty"`
-	FromBlock BlockTag `json:"fromBlock,omitempty"`
-	ToBlock   BlockTag `json:"toBlock,omitempty"`
-	Topics    []string `json:"topics,omitempty"`
+	BlockHash *types.Hash    `json:"blockHash,omitempty"`
+	Address   *types.Address `json:"address,omitempty"`
+	FromBlock BlockRef       `json:"fromBlock,omitempty"` // a tag or a number
+	ToBlock   BlockRef       `json:"toBlock,omitempty"`   // a tag or a number
+	Topics    []*types.Hash  `json:"topics,omitempty"`    // a nil topic matches any value
+}
+
+// MarshalJSON rejects a range given by block hashes, eth_getLogs takes a
+// block hash in BlockHash only. An empty tag is left out, as nil is.
+func (lp LogsParam) MarshalJSON() ([]byte, error) {
+	type logsParam LogsParam
+	for _, ref := range []*BlockRef{&lp.FromBlock, &lp.ToBlock} {
+		if tag, ok := (*ref).(BlockTag); ok && tag == "" {
+			*ref = nil
+		}
+		if *ref == nil {
+			continue
+		}
+		if _, err := blockNumberParam("eth_getLogs", *ref); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(logsParam(lp))
 }
 
 type LogsResult struct {
-	Address          string   `json:"address,omitempty"`
-	Topics           []string `json:"topics,omitempty"`
-	Data             string   `json:"data,omitempty"`
-	BlockNumber      string   `json:"blockNumber,omitempty"`
-	TransactionHash  string   `json:"transactionHash,omitempty"`
-	TransactionIndex string   `json:"transactionIndex,omitempty"`
-	BlockHash        string   `json:"BlockHash,omitempty"`
-	LogIndex         string   `json:"logIndex,omitempty"`
-	Removed          bool     `json:"removed,omitempty"`
+	Address          types.Address   `json:"address"`
+	Topics           []types.Hash    `json:"topics,omitempty"`
+	Data             types.Data      `json:"data,omitempty"`
+	BlockNumber      *types.Quantity `json:"blockNumber,omitempty"`
+	TransactionHash  types.Hash      `json:"transactionHash"`
+	TransactionIndex *types.Quantity `json:"transactionIndex,omitempty"`
+	BlockHash        types.Hash      `json:"blockHash"`
+	LogIndex         *types.Quantity `json:"logIndex,omitempty"`
+	Removed          bool            `json:"removed,omitempty"`
 }
 type LogsResults = []LogsResult
 
 //queries
-func (c *AlchemyClient) Eth_getLogs(lp []LogsParam) (*AlchemyResponse[LogsResults], error) {
+func (c *AlchemyClient) Eth_getLogs(ctx context.Context, lp []LogsParam) (*AlchemyResponse[LogsResults], error) {
 	j := JsonParams[LogsParam]{
 		Id:      1,
 		Jsonrpc: "2.0",
 		Method:  "eth_getLogs",
 		Params:  lp,
 	}
-	return executePost[LogsParam, LogsResults](c, j)
+	return executePost[LogsParam, LogsResults](ctx, c, j)
 }
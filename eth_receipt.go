@@ -0,0 +1,84 @@
+package goalchemysdk
+
+import (
+	"context"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// Receipt status, only set on post byzantium receipts
+const (
+	RECEIPT_STATUS_FAILURE = 0
+	RECEIPT_STATUS_SUCCESS = 1
+)
+
+// Receipt is the outcome of a mined transaction.
+type Receipt struct {
+	TransactionHash   types.Hash      `json:"transactionHash"`
+	TransactionIndex  *types.Quantity `json:"transactionIndex"`
+	BlockHash         types.Hash      `json:"blockHash"`
+	BlockNumber       *types.Quantity `json:"blockNumber"`
+	From              types.Address   `json:"from"`
+	To                *types.Address  `json:"to"`              // nil for contract creations
+	ContractAddress   *types.Address  `json:"contractAddress"` // created contract, if any
+	CumulativeGasUsed *types.Quantity `json:"cumulativeGasUsed"`
+	GasUsed           *types.Quantity `json:"gasUsed"`
+	EffectiveGasPrice *types.Quantity `json:"effectiveGasPrice,omitempty"`
+	BlobGasUsed       *types.Quantity `json:"blobGasUsed,omitempty"`
+	BlobGasPrice      *types.Quantity `json:"blobGasPrice,omitempty"`
+	Logs              []LogsResult    `json:"logs"`
+	LogsBloom         types.Data      `json:"logsBloom"`
+	Type              *types.Quantity `json:"type,omitempty"`
+	Status            *types.Quantity `json:"status,omitempty"`
+	Root              *types.Hash     `json:"root,omitempty"` // state root of pre byzantium receipts
+}
+
+// Succeeded tells if the transaction was executed without reverting.
+// Pre byzantium receipts have no status and are reported as succeeded.
+func (r *Receipt) Succeeded() bool {
+	return r.Status == nil || r.Status.Uint64() == RECEIPT_STATUS_SUCCESS
+}
+
+type TransactionReceiptParam = types.Hash
+
+// Eth_getTransactionReceipt returns the receipt of a transaction, nil while
+// the transaction is pending or unknown.
+func (c *AlchemyClient) Eth_getTransactionReceipt(ctx context.Context, hash TransactionReceiptParam) (*AlchemyResponse[*Receipt], error) {
+	j := JsonParams[TransactionReceiptParam]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_getTransactionReceipt",
+		Params:  []TransactionReceiptParam{hash},
+	}
+	return executePost[TransactionReceiptParam, *Receipt](ctx, c, j)
+}
+
+// TransactionReceiptsParam selects the block of alchemy_getTransactionReceipts,
+// either by number or by hash.
+type TransactionReceiptsParam struct {
+	BlockNumber BlockRef    `json:"blockNumber,omitempty"`
+	BlockHash   *types.Hash `json:"blockHash,omitempty"`
+}
+
+type TransactionReceiptsResult struct {
+	Receipts []Receipt `json:"receipts"`
+}
+
+// Alchemy_getTransactionReceipts returns every receipt of the block blk in
+// a single call. blk may be a tag, a number or a hash.
+func (c *AlchemyClient) Alchemy_getTransactionReceipts(ctx context.Context, blk BlockRef) (*AlchemyResponse[TransactionReceiptsResult], error) {
+	param := TransactionReceiptsParam{}
+	switch ref := blockParam(blk).(type) {
+	case BlockHashParam:
+		param.BlockHash = &ref.BlockHash
+	default:
+		param.BlockNumber = ref
+	}
+	j := JsonParams[TransactionReceiptsParam]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "alchemy_getTransactionReceipts",
+		Params:  []TransactionReceiptsParam{param},
+	}
+	return executePost[TransactionReceiptsParam, TransactionReceiptsResult](ctx, c, j)
+}
@@ -0,0 +1,130 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+const testReceipt = `{
+	"transactionHash":"0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3",
+	"transactionIndex":"0x1",
+	"blockHash":"0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb",
+	"blockNumber":"0x9579fbd",
+	"from":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+	"to":"0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
+	"contractAddress":null,
+	"cumulativeGasUsed":"0x5208",
+	"gasUsed":"0x5208",
+	"effectiveGasPrice":"0x3b9aca00",
+	"logs":[{"address":"0x2cde9919e81b20b4b33dd562a48a84b54c48f00c","topics":[],"data":"0x","logIndex":"0x2"}],
+	"logsBloom":"0x00",
+	"type":"0x2",
+	"status":"0x0"
+}`
+
+func TestReceipt_Succeeded(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *types.Quantity
+		want   bool
+	}{
+		{"success", types.Uint64Quantity(RECEIPT_STATUS_SUCCESS), true},
+		{"failure", types.Uint64Quantity(RECEIPT_STATUS_FAILURE), false},
+		{"pre byzantium", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Receipt{Status: tt.status}
+			if got := r.Succeeded(); got != tt.want {
+				t.Errorf("Receipt.Succeeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_Eth_getTransactionReceipt(t *testing.T) {
+	txHash := types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3")
+	sent := ""
+	answer := ""
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(answer), err
+		}),
+	}
+	ctx := context.Background()
+
+	answer = testReceipt
+	resp, err := c.Eth_getTransactionReceipt(ctx, txHash)
+	if err != nil {
+		t.Fatalf("AlchemyClient.Eth_getTransactionReceipt() error = %v", err)
+	}
+	if sent != `eth_getTransactionReceipt["`+txHash.Hex()+`"]` {
+		t.Errorf("AlchemyClient.Eth_getTransactionReceipt() sent %s", sent)
+	}
+	r := resp.Result
+	if r == nil || r.TransactionHash != txHash || r.Succeeded() || r.ContractAddress != nil || r.GasUsed.Uint64() != 21000 {
+		t.Fatalf("AlchemyClient.Eth_getTransactionReceipt() = %+v", r)
+	}
+	if len(r.Logs) != 1 || r.Logs[0].LogIndex.Uint64() != 2 {
+		t.Errorf("AlchemyClient.Eth_getTransactionReceipt() logs = %+v", r.Logs)
+	}
+
+	answer = `null`
+	resp, err = c.Eth_getTransactionReceipt(ctx, txHash)
+	if err != nil || resp.Result != nil {
+		t.Errorf("AlchemyClient.Eth_getTransactionReceipt() pending = %v, %v, want nil", resp.Result, err)
+	}
+}
+
+func TestAlchemyClient_Alchemy_getTransactionReceipts(t *testing.T) {
+	blockHash := "0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb"
+	sent := ""
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(`{"receipts":[` + testReceipt + `]}`), err
+		}),
+	}
+	tests := []struct {
+		name    string
+		blk     BlockRef
+		want    string
+		wantErr bool
+	}{
+		{"number", Number(0x9579fbd), `alchemy_getTransactionReceipts[{"blockNumber":"0x9579fbd"}]`, false},
+		{"tag", LATEST, `alchemy_getTransactionReceipts[{"blockNumber":"latest"}]`, false},
+		{"unset", nil, `alchemy_getTransactionReceipts[{"blockNumber":"latest"}]`, false},
+		{"hash", Hash(types.MustParseHash(blockHash), false), `alchemy_getTransactionReceipts[{"blockHash":"` + blockHash + `"}]`, false},
+		{"hash in tag", BlockTag(blockHash), `alchemy_getTransactionReceipts[{"blockHash":"` + blockHash + `"}]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent = ""
+			resp, err := c.Alchemy_getTransactionReceipts(context.Background(), tt.blk)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AlchemyClient.Alchemy_getTransactionReceipts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if sent != tt.want {
+				t.Errorf("AlchemyClient.Alchemy_getTransactionReceipts() sent %s, want %s", sent, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			hashes := []types.Hash{}
+			for _, r := range resp.Result.Receipts {
+				hashes = append(hashes, r.TransactionHash)
+			}
+			want := []types.Hash{types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3")}
+			if !reflect.DeepEqual(hashes, want) {
+				t.Errorf("AlchemyClient.Alchemy_getTransactionReceipts() receipts = %v, want %v", hashes, want)
+			}
+		})
+	}
+}
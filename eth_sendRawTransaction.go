@@ -0,0 +1,57 @@
+package goalchemysdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+type SendRawTransactionParam = types.Data
+
+type SendRawTransactionResult = types.Hash
+
+// Eth_sendRawTransaction broadcasts a signed transaction and returns its
+// hash.
+func (c *AlchemyClient) Eth_sendRawTransaction(ctx context.Context, raw SendRawTransactionParam) (*AlchemyResponse[SendRawTransactionResult], error) {
+	j := JsonParams[SendRawTransactionParam]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_sendRawTransaction",
+		Params:  []SendRawTransactionParam{raw},
+	}
+	return executePost[SendRawTransactionParam, SendRawTransactionResult](ctx, c, j)
+}
+
+// SignTransaction signs tx with signer for the client network. An unset
+// chain id is set to the network one, another one is refused so a
+// transaction cannot be signed for a chain it is not sent to.
+func (c *AlchemyClient) SignTransaction(signer *Signer, tx TypedTransaction) (*SignedTransaction, error) {
+	networkId, known := c.Network.ChainId()
+	chainId := chainIdOf(tx)
+	switch {
+	case chainId == nil && !known:
+		return nil, &AlchemyClientError{"SignTransaction", fmt.Sprintf("unknown chain id of network %s, set the transaction one", c.Network)}
+	case chainId == nil:
+		tx = withChainId(tx, types.Uint64Quantity(networkId))
+	case known && (!chainId.Big().IsUint64() || chainId.Uint64() != networkId):
+		return nil, &AlchemyClientError{"SignTransaction", fmt.Sprintf("chain id %s is not the one of network %s", chainId, c.Network)}
+	}
+	signed, err := signer.Sign(tx)
+	if err != nil {
+		return nil, &AlchemyClientError{"SignTransaction", err.Error()}
+	}
+	return signed, nil
+}
+
+// SendTransaction signs tx as SignTransaction does and broadcasts it.
+func (c *AlchemyClient) SendTransaction(ctx context.Context, signer *Signer, tx TypedTransaction) (*SignedTransaction, error) {
+	signed, err := c.SignTransaction(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Eth_sendRawTransaction(ctx, signed.Raw); err != nil {
+		return signed, err
+	}
+	return signed, nil
+}
@@ -0,0 +1,70 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+func TestNetwork_ChainId(t *testing.T) {
+	if id, ok := OPT_MAINNET.ChainId(); !ok || id != 10 {
+		t.Errorf("Network.ChainId() = %d, %v, want 10", id, ok)
+	}
+	if _, ok := Network("unknown").ChainId(); ok {
+		t.Errorf("Network.ChainId() unknown network found")
+	}
+}
+
+func TestAlchemyClient_SendTransaction(t *testing.T) {
+	s, err := NewSigner(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	to := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	sent := ""
+	c := &AlchemyClient{
+		Network: ARB_MAINNET,
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			b, err := json.Marshal(params)
+			sent = method + string(b)
+			return json.RawMessage(`"0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178"`), err
+		}),
+	}
+	tx := DynamicFeeTxn{Nonce: types.Uint64Quantity(1), MaxFeePerGas: types.Uint64Quantity(1e8), Gas: types.Uint64Quantity(21000), To: &to, Value: types.Uint64Quantity(1)}
+
+	signed, err := c.SendTransaction(context.Background(), s, tx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.SendTransaction() error = %v", err)
+	}
+	if id := signed.Transaction.(DynamicFeeTxn).ChainId.Uint64(); id != 42161 {
+		t.Errorf("AlchemyClient.SendTransaction() chain id = %d, want 42161", id)
+	}
+	if sent != `eth_sendRawTransaction["`+signed.Raw.Hex()+`"]` {
+		t.Errorf("AlchemyClient.SendTransaction() sent %s", sent)
+	}
+
+	tests := []struct {
+		name    string
+		network Network
+		chainId *types.Quantity
+		wantErr bool
+	}{
+		{"network chain id", ARB_MAINNET, types.Uint64Quantity(42161), false},
+		{"other chain id", ARB_MAINNET, types.Uint64Quantity(1), true},
+		{"unknown network", Network("custom"), nil, true},
+		{"unknown network with chain id", Network("custom"), types.Uint64Quantity(7), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &AlchemyClient{Network: tt.network}
+			tx := tx
+			tx.ChainId = tt.chainId
+			_, err := c.SignTransaction(s, tx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlchemyClient.SignTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// types
+
+// BlockHeader is the payload of a newHeads subscription event, and the
+// header part of a Block. Fields added by later forks are unset on blocks
+// which predate them.
+type BlockHeader struct {
+	Number                *types.Quantity `json:"number,omitempty"`
+	Hash                  types.Hash      `json:"hash"`
+	ParentHash            types.Hash      `json:"parentHash"`
+	Nonce                 types.Data      `json:"nonce,omitempty"`
+	Sha3Uncles            types.Hash      `json:"sha3Uncles"`
+	LogsBloom             types.Data      `json:"logsBloom,omitempty"`
+	TransactionsRoot      types.Hash      `json:"transactionsRoot"`
+	StateRoot             types.Hash      `json:"stateRoot"`
+	ReceiptsRoot          types.Hash      `json:"receiptsRoot"`
+	Miner                 types.Address   `json:"miner"`
+	Difficulty            *types.Quantity `json:"difficulty,omitempty"`
+	ExtraData             types.Data      `json:"extraData,omitempty"`
+	GasLimit              *types.Quantity `json:"gasLimit,omitempty"`
+	GasUsed               *types.Quantity `json:"gasUsed,omitempty"`
+	Timestamp             *types.Quantity `json:"timestamp,omitempty"`
+	MixHash               types.Hash      `json:"mixHash"`
+	BaseFeePerGas         *types.Quantity `json:"baseFeePerGas,omitempty"`
+	WithdrawalsRoot       *types.Hash     `json:"withdrawalsRoot,omitempty"`
+	BlobGasUsed           *types.Quantity `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas         *types.Quantity `json:"excessBlobGas,omitempty"`
+	ParentBeaconBlockRoot *types.Hash     `json:"parentBeaconBlockRoot,omitempty"`
+}
+
+// Subscription delivers the events of one eth_subscribe on Events.
+// Err receives decoding errors. Both channels are closed once the
+// subscription ends, either by Unsubscribe, by closing the websocket
+// client, or because the subscription could not be restored after a
+// reconnection; Err first receives the reason, if any.
+type Subscription[T any] struct {
+	ws     *WsClient
+	sub    *wsSubscription
+	events chan T
+}
+
+// Events returns the channel events are delivered on, closed when the
+// subscription ends. Events must be drained: a slow consumer stalls the
+// websocket reader.
+func (s *Subscription[T]) Events() <-chan T {
+	return s.events
+}
+
+// Err returns the channel subscription errors are delivered on.
+func (s *Subscription[T]) Err() <-chan error {
+	return s.sub.errs
+}
+
+// Unsubscribe sends eth_unsubscribe and ends the subscription.
+func (s *Subscription[T]) Unsubscribe(ctx context.Context) error {
+	return s.ws.unsubscribe(ctx, s.sub)
+}
+
+// wsSubscription is the untyped state of a subscription, kept by the
+// websocket client to route and replay it.
+type wsSubscription struct {
+	params      []interface{}
+	deliver     func(json.RawMessage)
+	closeEvents func()
+	errs        chan error
+	done        chan struct{}
+
+	sendMu sync.Mutex // held by deliver, so events are closed once it returns
+
+	mu    sync.Mutex
+	id    string
+	ended bool
+}
+
+func (s *wsSubscription) getId() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// register sets the id the server answered eth_subscribe with, and tells
+// if the subscription is still live to be routed under it.
+func (s *wsSubscription) register(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return false
+	}
+	s.id = id
+	return true
+}
+
+// end closes the subscription, reporting err first when not nil, then
+// closes its errors and events channels.
+func (s *wsSubscription) end(err error) {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	close(s.done)
+	if err != nil {
+		select {
+		case s.errs <- err:
+		default:
+		}
+	}
+	close(s.errs)
+	s.mu.Unlock()
+
+	// done is closed, a blocked deliver returns
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.closeEvents != nil {
+		s.closeEvents()
+	}
+}
+
+func subscribe[T any](ctx context.Context, ws *WsClient, params ...interface{}) (*Subscription[T], error) {
+	s := &Subscription[T]{
+		ws:     ws,
+		events: make(chan T, 16),
+	}
+	sub := &wsSubscription{
+		params: params,
+		errs:   make(chan error, 1),
+		done:   make(chan struct{}),
+	}
+	sub.deliver = func(raw json.RawMessage) {
+		sub.sendMu.Lock()
+		defer sub.sendMu.Unlock()
+		select {
+		case <-sub.done:
+			return
+		default:
+		}
+		var event T
+		if err := json.Unmarshal(raw, &event); err != nil {
+			sub.mu.Lock()
+			if !sub.ended {
+				select {
+				case sub.errs <- &AlchemyClientError{"eth_subscription", err.Error()}:
+				default:
+				}
+			}
+			sub.mu.Unlock()
+			return
+		}
+		select {
+		case s.events <- event:
+		case <-sub.done:
+		}
+	}
+	sub.closeEvents = func() { close(s.events) }
+	s.sub = sub
+
+	// the reader registers sub under the id the server answers
+	if _, err := ws.subscribe(ctx, sub); err != nil {
+		// ctx may expire once the server answered, its id is dropped
+		if id, live := ws.remove(sub); live {
+			go ws.drop(id)
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+//queries
+
+// SubscribeNewHeads emits a BlockHeader each time a block is added to the chain.
+func (ws *WsClient) SubscribeNewHeads(ctx context.Context) (*Subscription[BlockHeader], error) {
+	return subscribe[BlockHeader](ctx, ws, "newHeads")
+}
+
+// SubscribeLogs emits the logs matching filter that are part of newly added
+// blocks. Logs of re-orged blocks are sent again with Removed set.
+func (ws *WsClient) SubscribeLogs(ctx context.Context, filter LogsParam) (*Subscription[LogsResult], error) {
+	return subscribe[LogsResult](ctx, ws, "logs", filter)
+}
+
+// SubscribeNewPendingTransactions emits the hash of every transaction
+// added to the pending state.
+func (ws *WsClient) SubscribeNewPendingTransactions(ctx context.Context) (*Subscription[types.Hash], error) {
+	return subscribe[types.Hash](ctx, ws, "newPendingTransactions")
+}
@@ -1,37 +1,235 @@
 package goalchemysdk
 
-type TransactionByHashParam = string
+import (
+	"context"
+	"fmt"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+type TransactionByHashParam = types.Hash
 
 type TransactionByHashResult = TransactionJson
 
+// Transaction types
+const (
+	TX_TYPE_LEGACY      = 0x00
+	TX_TYPE_ACCESS_LIST = 0x01 // EIP-2930
+	TX_TYPE_DYNAMIC_FEE = 0x02 // EIP-1559
+	TX_TYPE_BLOB        = 0x03 // EIP-4844
+	TX_TYPE_SET_CODE    = 0x04 // EIP-7702
+)
+
+// AccessTuple is an entry of an EIP-2930 access list.
+type AccessTuple struct {
+	Address     types.Address `json:"address"`
+	StorageKeys []types.Hash  `json:"storageKeys"`
+}
+
+type AccessList []AccessTuple
+
+// Authorization is a signed EIP-7702 delegation of an account code to
+// Address.
+type Authorization struct {
+	ChainId *types.Quantity `json:"chainId"`
+	Address types.Address   `json:"address"`
+	Nonce   *types.Quantity `json:"nonce"`
+	YParity *types.Quantity `json:"yParity"`
+	R       *types.Quantity `json:"r"`
+	S       *types.Quantity `json:"s"`
+}
+
+// TransactionJson is a transaction of any type as returned by the api.
+// Fields a type does not have are unset, Typed gives a view holding only
+// the fields of its type.
 type TransactionJson struct {
-	BlockHash            string   `json:"blockHash,omitempty"`
-	BlockNumber          string   `json:"blockNumber,omitempty"`
-	Hash                 string   `json:"hash,omitempty"`
-	AccessList           []string `json:"accessList,omitempty"`
-	ChainId              string   `json:"chainId,omitempty"`
-	From                 string   `json:"from,omitempty"`
-	Gas                  string   `json:"gas,omitempty"`
-	GasPrice             string   `json:"gasPrice,omitempty"`
-	Input                string   `json:"input,omitempty"`
-	MaxFeePerGas         string   `json:"maxFeePerGas,omitempty"`
-	MaxPriorityFeePerGas string   `json:"maxPriorityFeePerGas,omitempty"`
-	Nonce                string   `json:"nonce,omitempty"`
-	R                    string   `json:"r,omitempty"`
-	S                    string   `json:"s,omitempty"`
-	To                   string   `json:"to,omitempty"`
-	TransactionIndex     string   `json:"transactionIndex,omitempty"`
-	Type                 string   `json:"type,omitempty"`
-	V                    string   `json:"v,omitempty"`
-	Value                string   `json:"value,omitempty"`
-}
-
-func (c *AlchemyClient) Eth_getTransactionByHash(ths []TransactionByHashParam) (*AlchemyResponse[TransactionByHashResult], error) {
+	BlockHash            *types.Hash     `json:"blockHash,omitempty"`
+	BlockNumber          *types.Quantity `json:"blockNumber,omitempty"`
+	Hash                 types.Hash      `json:"hash"`
+	AccessList           AccessList      `json:"accessList,omitempty"`
+	ChainId              *types.Quantity `json:"chainId,omitempty"`
+	From                 types.Address   `json:"from"`
+	Gas                  *types.Quantity `json:"gas,omitempty"`
+	GasPrice             *types.Quantity `json:"gasPrice,omitempty"`
+	Input                types.Data      `json:"input,omitempty"`
+	MaxFeePerGas         *types.Quantity `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *types.Quantity `json:"maxPriorityFeePerGas,omitempty"`
+	Nonce                *types.Quantity `json:"nonce,omitempty"`
+	R                    *types.Quantity `json:"r,omitempty"`
+	S                    *types.Quantity `json:"s,omitempty"`
+	To                   *types.Address  `json:"to,omitempty"` // nil for contract creations
+	TransactionIndex     *types.Quantity `json:"transactionIndex,omitempty"`
+	Type                 *types.Quantity `json:"type,omitempty"`
+	V                    *types.Quantity `json:"v,omitempty"`
+	Value                *types.Quantity `json:"value,omitempty"`
+	YParity              *types.Quantity `json:"yParity,omitempty"`
+	MaxFeePerBlobGas     *types.Quantity `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes  []types.Hash    `json:"blobVersionedHashes,omitempty"`
+	AuthorizationList    []Authorization `json:"authorizationList,omitempty"`
+}
+
+// TxType returns the transaction type, legacy when the api gives none.
+func (t *TransactionJson) TxType() uint64 {
+	return t.Type.Uint64()
+}
+
+// TypedTransaction is one of LegacyTxn, AccessListTxn, DynamicFeeTxn,
+// BlobTxn, SetCodeTxn or UnknownTxn.
+type TypedTransaction interface {
+	TxType() uint64
+}
+
+type LegacyTxn struct {
+	ChainId  *types.Quantity // nil before EIP-155
+	Nonce    *types.Quantity
+	GasPrice *types.Quantity
+	Gas      *types.Quantity
+	To       *types.Address
+	Value    *types.Quantity
+	Data     types.Data
+	V, R, S  *types.Quantity
+}
+
+type AccessListTxn struct {
+	ChainId    *types.Quantity
+	Nonce      *types.Quantity
+	GasPrice   *types.Quantity
+	Gas        *types.Quantity
+	To         *types.Address
+	Value      *types.Quantity
+	Data       types.Data
+	AccessList AccessList
+	YParity    *types.Quantity
+	R, S       *types.Quantity
+}
+
+type DynamicFeeTxn struct {
+	ChainId              *types.Quantity
+	Nonce                *types.Quantity
+	MaxPriorityFeePerGas *types.Quantity
+	MaxFeePerGas         *types.Quantity
+	Gas                  *types.Quantity
+	To                   *types.Address
+	Value                *types.Quantity
+	Data                 types.Data
+	AccessList           AccessList
+	YParity              *types.Quantity
+	R, S                 *types.Quantity
+}
+
+// BlobTxn carries blobs, it cannot create a contract.
+type BlobTxn struct {
+	ChainId              *types.Quantity
+	Nonce                *types.Quantity
+	MaxPriorityFeePerGas *types.Quantity
+	MaxFeePerGas         *types.Quantity
+	Gas                  *types.Quantity
+	To                   types.Address
+	Value                *types.Quantity
+	Data                 types.Data
+	AccessList           AccessList
+	MaxFeePerBlobGas     *types.Quantity
+	BlobVersionedHashes  []types.Hash
+	YParity              *types.Quantity
+	R, S                 *types.Quantity
+	Sidecar              *BlobSidecar // sent along when signed, never returned by the api
+}
+
+// BlobSidecar holds the blobs of a blob transaction with their KZG
+// commitments and proofs, one of each per versioned hash.
+type BlobSidecar struct {
+	Blobs       []types.Data
+	Commitments []types.Data
+	Proofs      []types.Data
+}
+
+// SetCodeTxn sets the code of the authorizing accounts, it cannot create
+// a contract.
+type SetCodeTxn struct {
+	ChainId              *types.Quantity
+	Nonce                *types.Quantity
+	MaxPriorityFeePerGas *types.Quantity
+	MaxFeePerGas         *types.Quantity
+	Gas                  *types.Quantity
+	To                   types.Address
+	Value                *types.Quantity
+	Data                 types.Data
+	AccessList           AccessList
+	AuthorizationList    []Authorization
+	YParity              *types.Quantity
+	R, S                 *types.Quantity
+}
+
+// UnknownTxn is a transaction of a type not listed above, such as the
+// deposit transactions of OP chains or the Arbitrum specific types. It
+// keeps the fields as the api gave them.
+type UnknownTxn struct {
+	TransactionJson
+}
+
+func (LegacyTxn) TxType() uint64     { return TX_TYPE_LEGACY }
+func (AccessListTxn) TxType() uint64 { return TX_TYPE_ACCESS_LIST }
+func (DynamicFeeTxn) TxType() uint64 { return TX_TYPE_DYNAMIC_FEE }
+func (BlobTxn) TxType() uint64       { return TX_TYPE_BLOB }
+func (SetCodeTxn) TxType() uint64    { return TX_TYPE_SET_CODE }
+func (t UnknownTxn) TxType() uint64  { return t.Type.Uint64() }
+
+// Typed returns the transaction as its type, a LegacyTxn, AccessListTxn,
+// DynamicFeeTxn, BlobTxn or SetCodeTxn, an UnknownTxn for other types.
+func (t *TransactionJson) Typed() (TypedTransaction, error) {
+	yParity := t.YParity
+	if yParity == nil {
+		// older nodes only give v, which is the y parity of typed transactions
+		yParity = t.V
+	}
+	switch t.TxType() {
+	case TX_TYPE_LEGACY:
+		return LegacyTxn{
+			ChainId: t.ChainId, Nonce: t.Nonce, GasPrice: t.GasPrice, Gas: t.Gas,
+			To: t.To, Value: t.Value, Data: t.Input,
+			V: t.V, R: t.R, S: t.S,
+		}, nil
+	case TX_TYPE_ACCESS_LIST:
+		return AccessListTxn{
+			ChainId: t.ChainId, Nonce: t.Nonce, GasPrice: t.GasPrice, Gas: t.Gas,
+			To: t.To, Value: t.Value, Data: t.Input, AccessList: t.AccessList,
+			YParity: yParity, R: t.R, S: t.S,
+		}, nil
+	case TX_TYPE_DYNAMIC_FEE:
+		return DynamicFeeTxn{
+			ChainId: t.ChainId, Nonce: t.Nonce, MaxPriorityFeePerGas: t.MaxPriorityFeePerGas, MaxFeePerGas: t.MaxFeePerGas, Gas: t.Gas,
+			To: t.To, Value: t.Value, Data: t.Input, AccessList: t.AccessList,
+			YParity: yParity, R: t.R, S: t.S,
+		}, nil
+	case TX_TYPE_BLOB:
+		if t.To == nil {
+			return nil, fmt.Errorf("blob transaction %s without recipient", t.Hash)
+		}
+		return BlobTxn{
+			ChainId: t.ChainId, Nonce: t.Nonce, MaxPriorityFeePerGas: t.MaxPriorityFeePerGas, MaxFeePerGas: t.MaxFeePerGas, Gas: t.Gas,
+			To: *t.To, Value: t.Value, Data: t.Input, AccessList: t.AccessList,
+			MaxFeePerBlobGas: t.MaxFeePerBlobGas, BlobVersionedHashes: t.BlobVersionedHashes,
+			YParity: yParity, R: t.R, S: t.S,
+		}, nil
+	case TX_TYPE_SET_CODE:
+		if t.To == nil {
+			return nil, fmt.Errorf("set code transaction %s without recipient", t.Hash)
+		}
+		return SetCodeTxn{
+			ChainId: t.ChainId, Nonce: t.Nonce, MaxPriorityFeePerGas: t.MaxPriorityFeePerGas, MaxFeePerGas: t.MaxFeePerGas, Gas: t.Gas,
+			To: *t.To, Value: t.Value, Data: t.Input, AccessList: t.AccessList, AuthorizationList: t.AuthorizationList,
+			YParity: yParity, R: t.R, S: t.S,
+		}, nil
+	}
+	return UnknownTxn{*t}, nil
+}
+
+func (c *AlchemyClient) Eth_getTransactionByHash(ctx context.Context, ths []TransactionByHashParam) (*AlchemyResponse[TransactionByHashResult], error) {
 	j := JsonParams[TransactionByHashParam]{
 		Id:      1,
 		Jsonrpc: "2.0",
 		Method:  "eth_getTransactionByHash",
 		Params:  ths,
 	}
-	return executePost[TransactionByHashParam, TransactionByHashResult](c, j)
+	return executePost[TransactionByHashParam, TransactionByHashResult](ctx, c, j)
 }
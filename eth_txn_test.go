@@ -1,17 +1,21 @@
 package goalchemysdk
 
 import (
-	"net/http"
+	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
-	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
 )
 
 
 func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
-	initEnvs()
+	c := &AlchemyClient{Transport: testNode}
+	blockHash := types.MustParseHash("0x8fabe002a1d4f368ac26435cf998e6d3fe408843ae6d193b7be4f49931d9ea97")
+	to := types.MustParseAddress("0x5957582f020301a2f732ad17a69ab2d8b2741241")
 	type args struct {
-		ths []TransactionByHashParam
+		ths []string
 	}
 	tests := []struct {
 		name    string
@@ -22,13 +26,7 @@ func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
 	}{
 		{
 			name: "test empty request",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				ths: nil,
 			},
@@ -39,17 +37,11 @@ func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
 				Result:  TransactionJson{},
 				Error:   ErrorExpectedAtLeastOneArgument,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test with results",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				ths: []string{"0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178"},
 			},
@@ -58,38 +50,32 @@ func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
 				Id:      1,
 				Jsonrpc: "2.0",
 				Result: TransactionJson{
-					BlockHash:            "0x8fabe002a1d4f368ac26435cf998e6d3fe408843ae6d193b7be4f49931d9ea97",
-					BlockNumber:          "0x4116290",
-					Hash:                 "0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178",
-					AccessList:           []string{},
-					ChainId:              "0xa4b1",
-					From:                 "0xeba9a3b3664ce4c950cba62ed372c7815cbbfd75",
-					Gas:                  "0xe5398",
-					GasPrice:             "0x5f5e100",
-					Input:                "0x287ad99a000000000000000000000000a6e249ffb81cf6f28ab021c3bd97620283c7335f000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000490a1",
-					MaxFeePerGas:         "0x80befc0",
-					MaxPriorityFeePerGas: "0x0",
-					Nonce:                "0x52",
-					R:                    "0xea114e9bd51b1d0cd3bf21f8c12dfb3cb2c9e9421c693d00784861ba50222f45",
-					S:                    "0x424eaebe2be7963bb739a45c1b318d8f0316e116ef31450f20e2fdacd9307ac7",
-					To:                   "0x5957582f020301a2f732ad17a69ab2d8b2741241",
-					TransactionIndex:     "0x1",
-					Type:                 "0x2",
-					V:                    "0x1",
-					Value:                "0x0",
+					BlockHash:            &blockHash,
+					BlockNumber:          types.MustParseQuantity("0x4116290"),
+					Hash:                 types.MustParseHash("0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178"),
+					AccessList:           AccessList{},
+					ChainId:              types.MustParseQuantity("0xa4b1"),
+					From:                 types.MustParseAddress("0xeba9a3b3664ce4c950cba62ed372c7815cbbfd75"),
+					Gas:                  types.MustParseQuantity("0xe5398"),
+					GasPrice:             types.MustParseQuantity("0x5f5e100"),
+					Input:                types.MustParseData("0x287ad99a000000000000000000000000a6e249ffb81cf6f28ab021c3bd97620283c7335f000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000490a1"),
+					MaxFeePerGas:         types.MustParseQuantity("0x80befc0"),
+					MaxPriorityFeePerGas: types.MustParseQuantity("0x0"),
+					Nonce:                types.MustParseQuantity("0x52"),
+					R:                    types.MustParseQuantity("0xea114e9bd51b1d0cd3bf21f8c12dfb3cb2c9e9421c693d00784861ba50222f45"),
+					S:                    types.MustParseQuantity("0x424eaebe2be7963bb739a45c1b318d8f0316e116ef31450f20e2fdacd9307ac7"),
+					To:                   &to,
+					TransactionIndex:     types.MustParseQuantity("0x1"),
+					Type:                 types.MustParseQuantity("0x2"),
+					V:                    types.MustParseQuantity("0x1"),
+					Value:                types.MustParseQuantity("0x0"),
 				},
 			},
 			wantErr: false,
 		},
 		{
 			name: "test multiples hashes error",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				ths: []string{"0xdeada6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178", "0xbeefa6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178"},
 			},
@@ -99,17 +85,11 @@ func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
 				Jsonrpc: "2.0",
 				Error:   ErrorTooManyArguments,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test wrong hash",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second *10,
-				},
-			},
+			c: c,
 			args: args{
 				ths: []string{"0xdeadbeef"},
 			},
@@ -119,12 +99,24 @@ func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
 				Jsonrpc: "2.0",
 				Error:   ErrorInvalidTxnHash,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.c.Eth_getTransactionByHash(tt.args.ths)
+			var ths []TransactionByHashParam
+			for _, th := range tt.args.ths {
+				hash, err := types.ParseHash(th)
+				if err != nil {
+					// malformed hashes fail before any request
+					if !tt.wantErr {
+						t.Errorf("types.ParseHash() error = %v", err)
+					}
+					return
+				}
+				ths = append(ths, hash)
+			}
+			got, err := tt.c.Eth_getTransactionByHash(context.Background(), ths)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.eth_getTransactionByHash() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -135,3 +127,114 @@ func TestAlchemyClient_eth_getTransactionByHash(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionJson_Typed(t *testing.T) {
+	to := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	slot := types.MustParseHash("0x0000000000000000000000000000000000000000000000000000000000000003")
+	accessList := AccessList{{Address: to, StorageKeys: []types.Hash{slot}}}
+	// an OP chain deposit updating the L1Block contract
+	depositBlock := types.MustParseHash("0x8fabe002a1d4f368ac26435cf998e6d3fe408843ae6d193b7be4f49931d9ea97")
+	l1Block := types.MustParseAddress("0x4200000000000000000000000000000000000015")
+	common := `"hash":"0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178","from":"0xeba9a3b3664ce4c950cba62ed372c7815cbbfd75","chainId":"0x1","nonce":"0x5","gas":"0x5208","value":"0x0","input":"0x","r":"0x1","s":"0x2"`
+	tests := []struct {
+		name    string
+		data    string
+		want    TypedTransaction
+		wantErr bool
+	}{
+		{
+			name: "legacy without type",
+			data: `{` + common + `,"to":"` + to.Hex() + `","gasPrice":"0x9","v":"0x25"}`,
+			want: LegacyTxn{
+				ChainId: types.Uint64Quantity(1), Nonce: types.Uint64Quantity(5), GasPrice: types.Uint64Quantity(9), Gas: types.Uint64Quantity(21000),
+				To: &to, Value: types.Uint64Quantity(0), Data: types.Data{},
+				V: types.Uint64Quantity(0x25), R: types.Uint64Quantity(1), S: types.Uint64Quantity(2),
+			},
+		},
+		{
+			name: "access list with v only",
+			data: `{` + common + `,"type":"0x1","to":"` + to.Hex() + `","gasPrice":"0x9","v":"0x1","accessList":[{"address":"` + to.Hex() + `","storageKeys":["` + slot.Hex() + `"]}]}`,
+			want: AccessListTxn{
+				ChainId: types.Uint64Quantity(1), Nonce: types.Uint64Quantity(5), GasPrice: types.Uint64Quantity(9), Gas: types.Uint64Quantity(21000),
+				To: &to, Value: types.Uint64Quantity(0), Data: types.Data{}, AccessList: accessList,
+				YParity: types.Uint64Quantity(1), R: types.Uint64Quantity(1), S: types.Uint64Quantity(2),
+			},
+		},
+		{
+			name: "dynamic fee contract creation",
+			data: `{` + common + `,"type":"0x2","maxFeePerGas":"0x7","maxPriorityFeePerGas":"0x3","yParity":"0x0","accessList":[]}`,
+			want: DynamicFeeTxn{
+				ChainId: types.Uint64Quantity(1), Nonce: types.Uint64Quantity(5), MaxPriorityFeePerGas: types.Uint64Quantity(3), MaxFeePerGas: types.Uint64Quantity(7), Gas: types.Uint64Quantity(21000),
+				Value: types.Uint64Quantity(0), Data: types.Data{}, AccessList: AccessList{},
+				YParity: types.Uint64Quantity(0), R: types.Uint64Quantity(1), S: types.Uint64Quantity(2),
+			},
+		},
+		{
+			name: "blob",
+			data: `{` + common + `,"type":"0x3","to":"` + to.Hex() + `","maxFeePerGas":"0x7","maxPriorityFeePerGas":"0x3","maxFeePerBlobGas":"0x4","blobVersionedHashes":["` + slot.Hex() + `"],"yParity":"0x1"}`,
+			want: BlobTxn{
+				ChainId: types.Uint64Quantity(1), Nonce: types.Uint64Quantity(5), MaxPriorityFeePerGas: types.Uint64Quantity(3), MaxFeePerGas: types.Uint64Quantity(7), Gas: types.Uint64Quantity(21000),
+				To: to, Value: types.Uint64Quantity(0), Data: types.Data{},
+				MaxFeePerBlobGas: types.Uint64Quantity(4), BlobVersionedHashes: []types.Hash{slot},
+				YParity: types.Uint64Quantity(1), R: types.Uint64Quantity(1), S: types.Uint64Quantity(2),
+			},
+		},
+		{
+			name: "set code",
+			data: `{` + common + `,"type":"0x4","to":"` + to.Hex() + `","maxFeePerGas":"0x7","maxPriorityFeePerGas":"0x3","yParity":"0x1","authorizationList":[{"chainId":"0x1","address":"` + to.Hex() + `","nonce":"0x6","yParity":"0x0","r":"0x1","s":"0x2"}]}`,
+			want: SetCodeTxn{
+				ChainId: types.Uint64Quantity(1), Nonce: types.Uint64Quantity(5), MaxPriorityFeePerGas: types.Uint64Quantity(3), MaxFeePerGas: types.Uint64Quantity(7), Gas: types.Uint64Quantity(21000),
+				To: to, Value: types.Uint64Quantity(0), Data: types.Data{},
+				AuthorizationList: []Authorization{{ChainId: types.Uint64Quantity(1), Address: to, Nonce: types.Uint64Quantity(6), YParity: types.Uint64Quantity(0), R: types.Uint64Quantity(1), S: types.Uint64Quantity(2)}},
+				YParity: types.Uint64Quantity(1), R: types.Uint64Quantity(1), S: types.Uint64Quantity(2),
+			},
+		},
+		{
+			name:    "blob without recipient",
+			data:    `{` + common + `,"type":"0x3"}`,
+			wantErr: true,
+		},
+		{
+			name: "op deposit",
+			data: `{"blockHash":"0x8fabe002a1d4f368ac26435cf998e6d3fe408843ae6d193b7be4f49931d9ea97","blockNumber":"0x7a1200","from":"0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001","gas":"0xf4240","gasPrice":"0x0","hash":"0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178","input":"0x440a5e20","nonce":"0x7a1200","to":"0x4200000000000000000000000000000000000015","transactionIndex":"0x0","value":"0x0","type":"0x7e","v":"0x0","r":"0x0","s":"0x0","sourceHash":"0x5ef1b35a1e6e4c6e4c5c1d0e8b6b9c2a2f1e4d3c2b1a09f8e7d6c5b4a3928170","mint":"0x0","isSystemTx":false,"depositReceiptVersion":"0x1"}`,
+			want: UnknownTxn{TransactionJson{
+				BlockHash:        &depositBlock,
+				BlockNumber:      types.Uint64Quantity(0x7a1200),
+				Hash:             types.MustParseHash("0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178"),
+				From:             types.MustParseAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001"),
+				Gas:              types.Uint64Quantity(0xf4240),
+				GasPrice:         types.Uint64Quantity(0),
+				Input:            types.MustParseData("0x440a5e20"),
+				Nonce:            types.Uint64Quantity(0x7a1200),
+				To:               &l1Block,
+				TransactionIndex: types.Uint64Quantity(0),
+				Type:             types.Uint64Quantity(0x7e),
+				V:                types.Uint64Quantity(0),
+				R:                types.Uint64Quantity(0),
+				S:                types.Uint64Quantity(0),
+				Value:            types.Uint64Quantity(0),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var txn TransactionJson
+			if err := json.Unmarshal([]byte(tt.data), &txn); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			got, err := txn.Typed()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransactionJson.Typed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// compared as json, big.Int internals differ for equal values
+			gotJson, _ := json.Marshal(got)
+			wantJson, _ := json.Marshal(tt.want)
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.want) || string(gotJson) != string(wantJson) {
+				t.Errorf("TransactionJson.Typed() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && got.TxType() != txn.TxType() {
+				t.Errorf("TypedTransaction.TxType() = %d, want %d", got.TxType(), txn.TxType())
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package goalchemysdk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// ErrUnknownEvent is wrapped by the errors of LogEvents.Decode for logs
+// of events it does not know.
+var ErrUnknownEvent = errors.New("unknown event")
+
+// Events of the ERC-20, ERC-721 and ERC-1155 token standards.
+var (
+	ERC20_EVENTS = abi.MustParseHuman(
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Approval(address indexed owner, address indexed spender, uint256 value)",
+	)
+	ERC721_EVENTS = abi.MustParseHuman(
+		"event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)",
+		"event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)",
+		"event ApprovalForAll(address indexed owner, address indexed operator, bool approved)",
+	)
+	ERC1155_EVENTS = abi.MustParseHuman(
+		"event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)",
+		"event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)",
+		"event ApprovalForAll(address indexed owner, address indexed operator, bool approved)",
+		"event URI(string value, uint256 indexed id)",
+	)
+)
+
+// STANDARD_EVENTS recognises the token standard events. ERC-20 and ERC-721
+// Transfer and Approval logs share their topic and are told apart by their
+// indexed inputs: value or tokenId.
+var STANDARD_EVENTS = LogEventsOf(ERC20_EVENTS, ERC721_EVENTS, ERC1155_EVENTS)
+
+// LogEvents maps event topics to the events logging them, many when they
+// only differ by which inputs are indexed.
+type LogEvents map[types.Hash][]abi.Event
+
+// LogEventsOf returns the events of parsed ABIs, anonymous ones left out
+// as their logs have no topic to be found by.
+func LogEventsOf(abis ...*abi.ABI) LogEvents {
+	events := LogEvents{}
+	for _, a := range abis {
+		for _, e := range a.Events {
+			if e.Anonymous || events.has(e) {
+				continue
+			}
+			events[e.Topic] = append(events[e.Topic], e)
+		}
+	}
+	return events
+}
+
+// has tells if an event logging the same topics as e is known.
+func (events LogEvents) has(e abi.Event) bool {
+	for _, known := range events[e.Topic] {
+		if indexedTypes(known) == indexedTypes(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func indexedTypes(e abi.Event) string {
+	var s string
+	for _, in := range e.Inputs {
+		if in.Indexed {
+			s += in.Type.String() + ","
+		}
+	}
+	return s
+}
+
+// Decode decodes log with the event of its first topic. A log none of the
+// events decodes gives an error wrapping ErrUnknownEvent.
+func (events LogEvents) Decode(log LogsResult) (*ContractEvent, error) {
+	if len(log.Topics) > 0 {
+		for _, e := range events[log.Topics[0]] {
+			if decoded, err := DecodeLog(log, e); err == nil {
+				return decoded, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: log %d of transaction %s", ErrUnknownEvent, log.LogIndex.Uint64(), log.TransactionHash)
+}
+
+// DecodeLog decodes a log of event, see abi.Event.Unpack: indexed
+// strings, bytes, arrays and tuples are only logged as their hash.
+func DecodeLog(log LogsResult, event abi.Event) (*ContractEvent, error) {
+	args, err := event.Unpack(log.Topics, log.Data)
+	if err != nil {
+		return nil, &AlchemyClientError{"DecodeLog", fmt.Sprintf("log %d of transaction %s: %v", log.LogIndex.Uint64(), log.TransactionHash, err)}
+	}
+	return &ContractEvent{Name: event.Name, Args: args, Log: log, event: event}, nil
+}
+
+// Map returns the decoded inputs by name, unnamed ones by position.
+func (e *ContractEvent) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(e.Args))
+	for i, in := range e.event.Inputs {
+		name := in.Name
+		if name == "" {
+			name = fmt.Sprint(i)
+		}
+		m[name] = e.Args[i]
+	}
+	return m
+}
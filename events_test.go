@@ -0,0 +1,160 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+func word(hex string) types.Hash {
+	return types.BytesToHash(types.MustParseData(hex))
+}
+
+func TestLogEvents_Decode(t *testing.T) {
+	from := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	to := types.MustParseAddress("0x000000000000000000000000000000000000dEaD")
+	transfer := types.MustParseHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	transferSingle := types.MustParseHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+	uri := ERC1155_EVENTS.Events[3].Topic
+	fromTopic, toTopic := word(from.Hex()), word(to.Hex())
+
+	tests := []struct {
+		name    string
+		log     LogsResult
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "erc20 transfer",
+			log:  LogsResult{Topics: []types.Hash{transfer, fromTopic, toTopic}, Data: word("0x03e8").Bytes()},
+			want: map[string]interface{}{"from": from, "to": to, "value": "1000"},
+		},
+		{
+			name: "erc721 transfer",
+			log:  LogsResult{Topics: []types.Hash{transfer, fromTopic, toTopic, word("0x2a")}},
+			want: map[string]interface{}{"from": from, "to": to, "tokenId": "42"},
+		},
+		{
+			name: "erc1155 transfer single",
+			log:  LogsResult{Topics: []types.Hash{transferSingle, fromTopic, fromTopic, toTopic}, Data: append(word("0x07").Bytes(), word("0x03e8").Bytes()...)},
+			want: map[string]interface{}{"operator": from, "from": from, "to": to, "id": "7", "value": "1000"},
+		},
+		{
+			name: "erc1155 uri",
+			log: LogsResult{
+				Topics: []types.Hash{uri, word("0x07")},
+				Data:   append(append(word("0x20").Bytes(), word("0x04").Bytes()...), append([]byte("ipfs"), make([]byte, 28)...)...),
+			},
+			want: map[string]interface{}{"value": "ipfs", "id": "7"},
+		},
+		{
+			name:    "unknown topic",
+			log:     LogsResult{Topics: []types.Hash{word("0x01")}},
+			wantErr: true,
+		},
+		{
+			name:    "no topic",
+			log:     LogsResult{},
+			wantErr: true,
+		},
+		{
+			name:    "transfer with unexpected topics",
+			log:     LogsResult{Topics: []types.Hash{transfer, fromTopic}, Data: word("0x03e8").Bytes()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := STANDARD_EVENTS.Decode(tt.log)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownEvent) {
+					t.Fatalf("LogEvents.Decode() error = %v, want ErrUnknownEvent", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LogEvents.Decode() error = %v", err)
+			}
+			m := got.Map()
+			if len(m) != len(tt.want) {
+				t.Fatalf("ContractEvent.Map() = %v, want %v", m, tt.want)
+			}
+			for name, want := range tt.want {
+				value := m[name]
+				if x, ok := value.(*big.Int); ok {
+					value = x.String()
+				}
+				if value != want {
+					t.Errorf("ContractEvent.Map()[%s] = %v, want %v", name, value, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLogEventsOf(t *testing.T) {
+	// ApprovalForAll is the same event in ERC-721 and ERC-1155
+	if got := len(STANDARD_EVENTS[ERC721_EVENTS.Events[2].Topic]); got != 1 {
+		t.Errorf("ApprovalForAll events = %d, want 1", got)
+	}
+	if got := len(STANDARD_EVENTS[ERC20_EVENTS.Events[0].Topic]); got != 2 {
+		t.Errorf("Transfer events = %d, want 2", got)
+	}
+	anonymous := abi.MustParseHuman("event Ping(uint256 indexed id) anonymous")
+	if got := LogEventsOf(anonymous); len(got) != 0 {
+		t.Errorf("LogEventsOf() = %v, want anonymous events left out", got)
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	event := abi.MustParseHuman("event Registered(string indexed name, address owner, bytes32)").Events[0]
+	owner := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	nameHash := types.BytesToHash(keccak256([]byte("alice")))
+	log := LogsResult{
+		Topics:          []types.Hash{event.Topic, nameHash},
+		Data:            append(word(owner.Hex()).Bytes(), word("0x01").Bytes()...),
+		TransactionHash: types.MustParseHash("0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb"),
+		LogIndex:        types.NewQuantity(big.NewInt(2)),
+	}
+	got, err := DecodeLog(log, event)
+	if err != nil {
+		t.Fatalf("DecodeLog() error = %v", err)
+	}
+	m := got.Map()
+	if got.Name != "Registered" || m["name"] != nameHash || m["owner"] != owner || !bytes.Equal(m["2"].([]byte), word("0x01").Bytes()) {
+		t.Errorf("DecodeLog() = %+v, map %v", got, m)
+	}
+	// with an unnamed input, fields are matched by position
+	var registered struct {
+		Name  types.Hash
+		Owner types.Address
+		Salt  [32]byte
+	}
+	if err := got.Copy(&registered); err != nil || registered.Name != nameHash || registered.Salt[31] != 1 {
+		t.Errorf("ContractEvent.Copy() = %+v, %v", registered, err)
+	}
+
+	// indexed static arrays and tuples are hashed too
+	pairAbi := abi.MustParseHuman("event Paired(uint256[2] indexed pair, (address,uint64) indexed order)")
+	pairLog := LogsResult{Topics: []types.Hash{pairAbi.Events[0].Topic, nameHash, word("0x01")}}
+	decoded, err := DecodeLog(pairLog, pairAbi.Events[0])
+	if err != nil {
+		t.Fatalf("DecodeLog() error = %v", err)
+	}
+	if m := decoded.Map(); m["pair"] != nameHash || m["order"] != word("0x01") {
+		t.Errorf("DecodeLog() = %v", m)
+	}
+	if _, err := LogEventsOf(pairAbi).Decode(pairLog); err != nil {
+		t.Errorf("LogEvents.Decode() error = %v", err)
+	}
+
+	log.Data = log.Data[:32]
+	if _, err := DecodeLog(log, event); err == nil || !strings.Contains(err.Error(), "log 2 of transaction 0x3ff6a0c1") {
+		t.Errorf("DecodeLog() error = %v, want short data", err)
+	}
+}
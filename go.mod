@@ -4,5 +4,10 @@ go 1.21
 
 require (
 	github.com/avast/retry-go/v4 v4.5.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.3.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
+	golang.org/x/crypto v0.17.0
 )
+
+require golang.org/x/sys v0.15.0 // indirect
@@ -0,0 +1,13 @@
+package goalchemysdk
+
+import "golang.org/x/crypto/sha3"
+
+// keccak256 is the legacy Keccak-256 hash used by Ethereum, not the
+// standardised SHA3-256.
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
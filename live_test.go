@@ -0,0 +1,70 @@
+package goalchemysdk
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// TestLive_smoke is the only test run against the live Alchemy api, it
+// checks the offline fixtures still match the chain. It is skipped when
+// ALCHEMY_API_KEY is not set.
+func TestLive_smoke(t *testing.T) {
+	initEnvs(t)
+	c := &AlchemyClient{
+		ApiKey:  ALCHEMY_API_KEY_TEST,
+		Network: ARB_MAINNET,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+	arbToken := types.MustParseAddress("0x912ce59144191c1204e64559fe8253a0e49e6548")
+
+	t.Run("eth_getLogs", func(t *testing.T) {
+		got, err := c.Eth_getLogs(context.Background(), []LogsParam{{BlockHash: &testLogsBlockHash, Address: &testLogsAddress}})
+		if err != nil {
+			t.Fatalf("AlchemyClient.Eth_getLogs() error = %v", err)
+		}
+		if !reflect.DeepEqual(got.Result, LogsResults{testLog}) {
+			t.Errorf("AlchemyClient.Eth_getLogs() = %v, want %v", got.Result, LogsResults{testLog})
+		}
+	})
+
+	t.Run("eth_getCode", func(t *testing.T) {
+		got, err := c.Eth_getCode(context.Background(), arbToken, LATEST)
+		if err != nil {
+			t.Fatalf("AlchemyClient.Eth_getCode() error = %v", err)
+		}
+		if got.Result.Hex() != testArbTokenCode {
+			t.Errorf("AlchemyClient.Eth_getCode() = %v, want the ARB token proxy code", got.Result)
+		}
+	})
+
+	t.Run("DetectProxyTarget", func(t *testing.T) {
+		want := types.MustParseAddress("0xc4ed0a9ea70d5bcc69f748547650d32cc219d882")
+		got, err := c.DetectProxyTarget(context.Background(), arbToken, LATEST)
+		if err != nil {
+			t.Fatalf("AlchemyClient.DetectProxyTarget() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("AlchemyClient.DetectProxyTarget() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("wrong api key", func(t *testing.T) {
+		wrong := &AlchemyClient{
+			ApiKey:       "123456",
+			Network:      ARB_MAINNET,
+			BaseUrlApiV2: BASE_API_URL_V2,
+			netClient:    c.netClient,
+		}
+		got, err := wrong.Eth_getLogs(context.Background(), []LogsParam{{BlockHash: &testLogsBlockHash, Address: &testLogsAddress}})
+		if err == nil || got.Error != ErrorMustBeAuthenticated {
+			t.Errorf("AlchemyClient.Eth_getLogs() = %v, %v, want %v", got, err, ErrorMustBeAuthenticated)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package goalchemysdk
+
+import (
+	"context"
+	"log/slog"
+)
+
+var silentLogger = slog.New(discardHandler{})
+
+func (c *AlchemyClient) log() *slog.Logger {
+	if c.logger == nil {
+		return silentLogger
+	}
+	return c.logger
+}
+
+// discardHandler drops every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
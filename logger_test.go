@@ -0,0 +1,74 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAlchemyClient_log(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Add("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":1,"jsonrpc":"2.0","result":"0x1"}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c := &AlchemyClient{
+		ApiKey:       "key",
+		BaseUrlApiV2: ts.URL,
+		MaxRetry:     2,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+	WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))(c)
+
+	j := JsonParams[string]{Id: 1, Jsonrpc: "2.0", Method: "eth_blockNumber"}
+	if _, err := executePost[string, string](context.Background(), c, j); err != nil {
+		t.Fatalf("executePost() error = %v", err)
+	}
+
+	var records []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record map[string]interface{}
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("invalid log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d log records, want 3: %v", len(records), records)
+	}
+	failed, retried, succeeded := records[0], records[1], records[2]
+	if failed["msg"] != "alchemy request failed" || failed["method"] != "eth_blockNumber" ||
+		failed["attempt"] != float64(1) || failed["status"] != float64(429) || failed["retry_after"] != "1" {
+		t.Errorf("failed attempt record = %v", failed)
+	}
+	if _, ok := failed["latency"]; !ok {
+		t.Errorf("failed attempt record has no latency: %v", failed)
+	}
+	if retried["msg"] != "alchemy request retry" || retried["attempt"] != float64(2) || retried["delay"] != float64(time.Second) {
+		t.Errorf("retry record = %v", retried)
+	}
+	if succeeded["level"] != "DEBUG" || succeeded["attempt"] != float64(2) || succeeded["status"] != float64(200) {
+		t.Errorf("successful attempt record = %v", succeeded)
+	}
+
+	if silent := (&AlchemyClient{}).log(); silent.Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("default logger is not silent")
+	}
+}
@@ -0,0 +1,336 @@
+package goalchemysdk
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	LOGS_CHUNK_SIZE_DEFAULT  = 2000 // blocks queried by one eth_getLogs of GetLogsPaged
+	LOGS_CONCURRENCY_DEFAULT = 4    // eth_getLogs requests of GetLogsPaged in flight
+)
+
+// logsRangeRe matches the block range suggested by the errors of
+// eth_getLogs queries returning too many logs, such as "query returned
+// more than 10000 results. Try with this block range [0x0, 0x1d3e]."
+var logsRangeRe = regexp.MustCompile(`\[(0x[0-9a-fA-F]+),\s*(0x[0-9a-fA-F]+)\]`)
+
+// SuggestedLogsRange returns the block range an eth_getLogs error
+// suggests querying instead, false when err suggests none.
+func SuggestedLogsRange(err error) (from uint64, to uint64, ok bool) {
+	var apiErr *AlchemyApiError
+	if !errors.As(err, &apiErr) {
+		return 0, 0, false
+	}
+	m := logsRangeRe.FindStringSubmatch(apiErr.Message)
+	if m == nil {
+		m = logsRangeRe.FindStringSubmatch(string(apiErr.Data))
+	}
+	if m == nil {
+		return 0, 0, false
+	}
+	from, errFrom := strconv.ParseUint(m[1][2:], 16, 64)
+	to, errTo := strconv.ParseUint(m[2][2:], 16, 64)
+	if errFrom != nil || errTo != nil || to < from {
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// logsRangeExceeded tells if the error rejects an eth_getLogs block range
+// or its number of logs as too large.
+func (ae *AlchemyApiError) logsRangeExceeded() bool {
+	if _, _, ok := SuggestedLogsRange(ae); ok {
+		return true
+	}
+	msg := strings.ToLower(ae.Message)
+	return strings.Contains(msg, "block range") || strings.Contains(msg, "response size exceeded") || strings.Contains(msg, "more than 10000 results")
+}
+
+// LogsIterator yields the logs of GetLogsPaged in block order.
+type LogsIterator struct {
+	Log LogsResult // the current log
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	chunks chan *logsChunk // in block order
+	logs   []LogsResult    // rest of the current chunk
+	done   bool
+	err    error
+}
+
+// logsChunk is a block range fetched by one worker, done is closed once
+// its logs or error are set.
+type logsChunk struct {
+	from, to uint64
+	logs     []LogsResult
+	err      error
+	done     chan struct{}
+}
+
+// logsPager fetches the chunks of a GetLogsPaged query, sharing the
+// chunk size its workers shrink.
+type logsPager struct {
+	client *AlchemyClient
+	lp     LogsParam
+	mu     sync.Mutex
+	size   uint64
+}
+
+// GetLogsPaged returns the logs matching lp, splitting its block range in
+// chunks of chunkSize blocks fetched by up to concurrency eth_getLogs
+// requests at a time; 0 gives the defaults. Tags in the range are
+// resolved to block numbers once, before the first chunk.
+// A chunk rejected for returning too many logs is queried again in the
+// range the error suggests, or halved when none is, and later chunks are
+// no larger. Logs are yielded in order whatever order chunks complete in:
+//
+//	it := client.GetLogsPaged(ctx, lp, 0, 0)
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Log.TransactionHash)
+//	}
+//	if err := it.Error(); err != nil {
+//		...
+//	}
+//
+// A query by BlockHash is sent as is.
+func (c *AlchemyClient) GetLogsPaged(ctx context.Context, lp LogsParam, chunkSize uint64, concurrency int) *LogsIterator {
+	if chunkSize == 0 {
+		chunkSize = LOGS_CHUNK_SIZE_DEFAULT
+	}
+	if concurrency <= 0 {
+		concurrency = LOGS_CONCURRENCY_DEFAULT
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	it := &LogsIterator{ctx: ctx, cancel: cancel, chunks: make(chan *logsChunk, concurrency)}
+	p := &logsPager{client: c, lp: lp, size: chunkSize}
+	go p.run(ctx, it.chunks, concurrency)
+	return it
+}
+
+// Next moves to the next log. It returns false once all logs are yielded,
+// on error, see Error, or after Close.
+func (it *LogsIterator) Next() bool {
+	for len(it.logs) == 0 {
+		if it.done {
+			return false
+		}
+		var chunk *logsChunk
+		var ok bool
+		select {
+		case chunk, ok = <-it.chunks:
+		case <-it.ctx.Done():
+			return it.fail(it.ctx.Err())
+		}
+		if !ok {
+			it.Close()
+			return false
+		}
+		select {
+		case <-chunk.done:
+		case <-it.ctx.Done():
+			return it.fail(it.ctx.Err())
+		}
+		if chunk.err != nil {
+			return it.fail(chunk.err)
+		}
+		it.logs = chunk.logs
+	}
+	it.Log, it.logs = it.logs[0], it.logs[1:]
+	return true
+}
+
+func (it *LogsIterator) fail(err error) bool {
+	it.err = err
+	it.Close()
+	return false
+}
+
+// Error returns the error that stopped the iteration, nil once all logs
+// are yielded.
+func (it *LogsIterator) Error() error {
+	return it.err
+}
+
+// Close stops the iteration and the requests in flight. It must be called
+// when stopping before Next returns false.
+func (it *LogsIterator) Close() {
+	it.done, it.logs = true, nil
+	it.cancel()
+}
+
+// run queues the chunks of the query in order on chunks, and has
+// concurrency workers fetch them.
+func (p *logsPager) run(ctx context.Context, chunks chan<- *logsChunk, concurrency int) {
+	defer close(chunks)
+	if p.lp.BlockHash != nil {
+		chunk := &logsChunk{done: make(chan struct{})}
+		chunks <- chunk
+		resp, err := p.client.Eth_getLogs(ctx, []LogsParam{p.lp})
+		if err == nil {
+			chunk.logs = resp.Result
+		}
+		chunk.err = err
+		close(chunk.done)
+		return
+	}
+	from, to, err := p.blockRange(ctx)
+	if err != nil {
+		chunk := &logsChunk{err: err, done: make(chan struct{})}
+		close(chunk.done)
+		chunks <- chunk
+		return
+	}
+
+	work := make(chan *logsChunk)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range work {
+				chunk.logs, chunk.err = p.fetch(ctx, chunk.from, chunk.to)
+				close(chunk.done)
+			}
+		}()
+	}
+	defer func() {
+		close(work)
+		wg.Wait()
+	}()
+
+	for start := from; start <= to; {
+		end := to
+		if size := p.chunkSize(); to-start >= size {
+			end = start + size - 1
+		}
+		chunk := &logsChunk{from: start, to: end, done: make(chan struct{})}
+		// chunks bounds how far fetching runs ahead of the reader
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case work <- chunk:
+		case <-ctx.Done():
+			return
+		}
+		if end == to {
+			return
+		}
+		start = end + 1
+	}
+}
+
+// fetch returns the logs of blocks from to to, in as many requests as the
+// chunk size allows.
+func (p *logsPager) fetch(ctx context.Context, from uint64, to uint64) ([]LogsResult, error) {
+	var logs []LogsResult
+	for {
+		end := to
+		if size := p.chunkSize(); to-from >= size {
+			end = from + size - 1
+		}
+		lp := p.lp
+		lp.FromBlock, lp.ToBlock = BlockNumber(from), BlockNumber(end)
+		resp, err := p.client.Eth_getLogs(ctx, []LogsParam{lp})
+		if err != nil {
+			if !p.shrink(err, end-from+1) {
+				return nil, err
+			}
+			continue
+		}
+		logs = append(logs, resp.Result...)
+		if end == to {
+			return logs, nil
+		}
+		from = end + 1
+	}
+}
+
+func (p *logsPager) chunkSize() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size
+}
+
+// shrink lowers the chunk size below queried, the number of blocks err
+// rejected, and tells if it did.
+func (p *logsPager) shrink(err error, queried uint64) bool {
+	var apiErr *AlchemyApiError
+	if !errors.As(err, &apiErr) || !apiErr.logsRangeExceeded() {
+		return false
+	}
+	size := queried / 2
+	if from, to, ok := SuggestedLogsRange(err); ok && to-from < math.MaxUint64 {
+		size = to - from + 1
+	}
+	if size == 0 || size >= queried {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if size < p.size {
+		p.size = size
+	}
+	return true
+}
+
+// blockRange resolves the range of the query to block numbers.
+func (p *logsPager) blockRange(ctx context.Context) (uint64, uint64, error) {
+	from, err := p.client.blockNumberOf(ctx, p.lp.FromBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := p.client.blockNumberOf(ctx, p.lp.ToBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+// blockNumberOf returns the number of the block ref, a tag or a number,
+// latest when unset as for eth_getLogs.
+func (c *AlchemyClient) blockNumberOf(ctx context.Context, ref BlockRef) (uint64, error) {
+	ref, err := blockNumberParam("GetLogsPaged", ref)
+	if err != nil {
+		return 0, err
+	}
+	switch r := ref.(type) {
+	case BlockNumber:
+		return uint64(r), nil
+	case BlockTag:
+		switch r {
+		case EARLIEST:
+			return 0, nil
+		case LATEST, PENDING:
+			resp, err := c.Eth_blockNumber(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return uint64(resp.Result), nil
+		case SAFE, FINALIZED:
+			resp, err := c.Eth_getBlockByNumber(ctx, r, false)
+			if err != nil {
+				return 0, err
+			}
+			if resp.Result == nil || resp.Result.Number == nil {
+				return 0, &AlchemyClientError{"GetLogsPaged", "no " + string(r) + " block"}
+			}
+			return resp.Result.Number.Uint64(), nil
+		}
+		var n BlockNumber
+		if err := n.UnmarshalJSON([]byte(strconv.Quote(string(r)))); err != nil {
+			return 0, &AlchemyClientError{"GetLogsPaged", err.Error()}
+		}
+		return uint64(n), nil
+	}
+	return 0, &AlchemyClientError{"GetLogsPaged", "unsupported block reference"}
+}
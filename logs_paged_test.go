@@ -0,0 +1,245 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// fakeLogsNode answers eth_getLogs with one log per block, up to head,
+// unless reject fails the range.
+type fakeLogsNode struct {
+	head   uint64
+	reject func(from, to uint64) error // nil accepts every range
+
+	mu     sync.Mutex
+	ranges []string // queried ranges, in order of arrival
+}
+
+func (n *fakeLogsNode) transport() Transport {
+	return TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		switch method {
+		case "eth_blockNumber":
+			return json.Marshal(fmt.Sprintf("0x%x", n.head))
+		case "eth_getLogs":
+		default:
+			return nil, fmt.Errorf("unexpected %s", method)
+		}
+		b, _ := json.Marshal(params[0])
+		var q struct {
+			BlockHash *types.Hash
+			FromBlock BlockNumber
+			ToBlock   BlockNumber
+		}
+		if err := json.Unmarshal(b, &q); err != nil {
+			return nil, err
+		}
+		if q.BlockHash != nil {
+			n.record("hash")
+			return json.Marshal([]LogsResult{{BlockHash: *q.BlockHash}})
+		}
+		from, to := uint64(q.FromBlock), uint64(q.ToBlock)
+		n.record(fmt.Sprintf("%d-%d", from, to))
+		if n.reject != nil {
+			if err := n.reject(from, to); err != nil {
+				return nil, err
+			}
+		}
+		// later blocks answer first, logs must still come in order
+		time.Sleep(time.Duration(20-from%20) * time.Millisecond / 10)
+		var logs []LogsResult
+		for blk := from; blk <= to && blk <= n.head; blk++ {
+			logs = append(logs, LogsResult{BlockNumber: types.NewQuantity(new(big.Int).SetUint64(blk))})
+		}
+		return json.Marshal(logs)
+	})
+}
+
+func (n *fakeLogsNode) record(r string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.ranges = append(n.ranges, r)
+}
+
+// blocks returns the blocks of the logs yielded by it.
+func blocks(it *LogsIterator) string {
+	var got []string
+	for it.Next() {
+		got = append(got, fmt.Sprint(it.Log.BlockNumber.Uint64()))
+	}
+	return strings.Join(got, ",")
+}
+
+func TestAlchemyClient_GetLogsPaged(t *testing.T) {
+	// both accept two blocks at most
+	suggest := func(from, to uint64) error {
+		if to-from < 2 {
+			return nil
+		}
+		return &AlchemyApiError{Code: CODE_LIMIT_EXCEEDED, Message: fmt.Sprintf("query returned more than 10000 results. Try with this block range [0x%x, 0x%x].", from, from+1)}
+	}
+	tooLarge := func(from, to uint64) error {
+		if to-from < 2 {
+			return nil
+		}
+		return &AlchemyApiError{Code: CODE_INVALID_PARAMS, Message: "eth_getLogs block range is too large"}
+	}
+	tests := []struct {
+		name        string
+		node        *fakeLogsNode
+		lp          LogsParam
+		chunkSize   uint64
+		concurrency int
+		want        string
+		wantRanges  []string // in order, nil to skip
+	}{
+		{
+			name:        "chunks in order",
+			node:        &fakeLogsNode{head: 100},
+			lp:          LogsParam{FromBlock: BlockNumber(0), ToBlock: BlockNumber(9)},
+			chunkSize:   3,
+			concurrency: 3,
+			want:        "0,1,2,3,4,5,6,7,8,9",
+		},
+		{
+			name:        "suggested range",
+			node:        &fakeLogsNode{head: 100, reject: suggest},
+			lp:          LogsParam{FromBlock: BlockNumber(10), ToBlock: BlockNumber(16)},
+			chunkSize:   5,
+			concurrency: 1,
+			want:        "10,11,12,13,14,15,16",
+			wantRanges:  []string{"10-14", "10-11", "12-13", "14-14", "15-16"},
+		},
+		{
+			name:        "halved without suggestion",
+			node:        &fakeLogsNode{head: 100, reject: tooLarge},
+			lp:          LogsParam{FromBlock: BlockNumber(0), ToBlock: BlockNumber(7)},
+			chunkSize:   8,
+			concurrency: 1,
+			want:        "0,1,2,3,4,5,6,7",
+			wantRanges:  []string{"0-7", "0-3", "0-1", "2-3", "4-5", "6-7"},
+		},
+		{
+			name:        "tags resolved",
+			node:        &fakeLogsNode{head: 4},
+			lp:          LogsParam{FromBlock: EARLIEST, ToBlock: LATEST},
+			chunkSize:   2,
+			concurrency: 2,
+			want:        "0,1,2,3,4",
+		},
+		{
+			name:        "hex number tag",
+			node:        &fakeLogsNode{head: 100},
+			lp:          LogsParam{FromBlock: BlockTag("0x5"), ToBlock: BlockNumber(6)},
+			concurrency: 1,
+			want:        "5,6",
+			wantRanges:  []string{"5-6"},
+		},
+		{
+			name:       "empty range",
+			node:       &fakeLogsNode{head: 100},
+			lp:         LogsParam{FromBlock: BlockNumber(6), ToBlock: BlockNumber(5)},
+			want:       "",
+			wantRanges: []string{},
+		},
+		{
+			name:       "block hash",
+			node:       &fakeLogsNode{},
+			lp:         LogsParam{BlockHash: &types.Hash{1}},
+			want:       "0",
+			wantRanges: []string{"hash"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &AlchemyClient{Transport: tt.node.transport()}
+			it := c.GetLogsPaged(context.Background(), tt.lp, tt.chunkSize, tt.concurrency)
+			defer it.Close()
+			if got := blocks(it); got != tt.want || it.Error() != nil {
+				t.Fatalf("GetLogsPaged() = %s, %v, want %s", got, it.Error(), tt.want)
+			}
+			if tt.wantRanges != nil && strings.Join(tt.node.ranges, " ") != strings.Join(tt.wantRanges, " ") {
+				t.Errorf("GetLogsPaged() queried %v, want %v", tt.node.ranges, tt.wantRanges)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_GetLogsPaged_errors(t *testing.T) {
+	invalid := &AlchemyApiError{Code: CODE_INVALID_PARAMS, Message: "invalid topic"}
+	node := &fakeLogsNode{head: 100, reject: func(from, to uint64) error {
+		if from >= 4 {
+			return invalid
+		}
+		return nil
+	}}
+	c := &AlchemyClient{Transport: node.transport()}
+
+	// logs of the chunks before the failing one are yielded
+	it := c.GetLogsPaged(context.Background(), LogsParam{FromBlock: BlockNumber(0), ToBlock: BlockNumber(9)}, 2, 2)
+	if got := blocks(it); got != "0,1,2,3" || !errors.Is(it.Error(), invalid) {
+		t.Errorf("GetLogsPaged() = %s, %v, want %v", got, it.Error(), invalid)
+	}
+	if it.Next() {
+		t.Errorf("LogsIterator.Next() = true after an error")
+	}
+
+	it = c.GetLogsPaged(context.Background(), LogsParam{FromBlock: Hash(types.MustParseHash("0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb"), false)}, 0, 0)
+	if it.Next() || it.Error() == nil || !strings.Contains(it.Error().Error(), "block hash is not accepted") {
+		t.Errorf("GetLogsPaged() range by hash error = %v", it.Error())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	node = &fakeLogsNode{head: 100}
+	c = &AlchemyClient{Transport: node.transport()}
+	it = c.GetLogsPaged(ctx, LogsParam{FromBlock: BlockNumber(0), ToBlock: BlockNumber(99)}, 1, 2)
+	if !it.Next() {
+		t.Fatalf("GetLogsPaged() error = %v", it.Error())
+	}
+	cancel()
+	for it.Next() {
+	}
+	if !errors.Is(it.Error(), context.Canceled) {
+		t.Errorf("GetLogsPaged() canceled error = %v", it.Error())
+	}
+
+	// closing early is not an error
+	it = c.GetLogsPaged(context.Background(), LogsParam{FromBlock: BlockNumber(0), ToBlock: BlockNumber(99)}, 1, 2)
+	it.Next()
+	it.Close()
+	if it.Next() || it.Error() != nil {
+		t.Errorf("LogsIterator after Close = %v", it.Error())
+	}
+}
+
+func TestSuggestedLogsRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		from, to uint64
+		ok       bool
+	}{
+		{"more than 10000 results", &AlchemyApiError{Code: CODE_LIMIT_EXCEEDED, Message: "query returned more than 10000 results. Try with this block range [0x10, 0x1D3E]."}, 0x10, 0x1d3e, true},
+		{"response size exceeded", fmt.Errorf("eth_getLogs: %w", &AlchemyApiError{Code: CODE_INVALID_PARAMS, Message: "Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range and no limit on the response size, or you can request any block range with a cap of 10K logs in the response. Based on your parameters, this block range should work: [0x0, 0x7cf]"}), 0, 0x7cf, true},
+		{"in data", &AlchemyApiError{Code: CODE_LIMIT_EXCEEDED, Message: "limit exceeded", Data: `{"from":"0x1","to":"0x2","range":"[0x1, 0x2]"}`}, 1, 2, true},
+		{"reversed", &AlchemyApiError{Message: "try [0x2, 0x1]"}, 0, 0, false},
+		{"no range", &AlchemyApiError{Code: CODE_LIMIT_EXCEEDED, Message: "limit exceeded"}, 0, 0, false},
+		{"other error", errors.New("[0x1, 0x2]"), 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok := SuggestedLogsRange(tt.err)
+			if from != tt.from || to != tt.to || ok != tt.ok {
+				t.Errorf("SuggestedLogsRange() = %d, %d, %v, want %d, %d, %v", from, to, ok, tt.from, tt.to, tt.ok)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package goalchemysdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -16,15 +18,21 @@ import (
 
 const BASE_API_URL_V2 = ".g.alchemy.com/v2"
 const MAX_RETRY_DEFAULT = 3
-const DELAY_DEFAULT = 1
+const DELAY_DEFAULT = 100 // milliseconds, as RETRY_BASE_DELAY_DEFAULT
 
 type AlchemyClient struct {
 	ApiKey       string
 	Network      Network
 	MaxRetry     uint
-	Delay        uint
+	Delay        uint // milliseconds before the first retry
 	BaseUrlApiV2 string // base url if empty deafault is used
-	netClient  *http.Client
+	Transport    Transport // requests are posted over https when nil
+	netClient    *http.Client
+	logger       *slog.Logger
+	policy       *RetryPolicy
+	limiter      *RateLimiter
+	customErrors CustomErrors
+	pollInterval time.Duration
 }
 
 type AlchemyClientError struct {
@@ -37,7 +45,9 @@ func (e *AlchemyClientError) Error() string {
 }
 
 
-// Initialiises a client
+// Initialiises a client, delay is in milliseconds, see WithRetry.
+//
+// Deprecated: use NewClient, which takes its optional settings as options.
 func (c *AlchemyClient) Init(apiKey string, network Network, maxRetry uint, delay uint, baseUrlApiV2 string, timeout time.Duration) error {
 	c.ApiKey = apiKey
 	c.Network = network
@@ -86,80 +96,189 @@ func (c *AlchemyClient) getApiUrl() (string,error){
 
 var _ error = (*RetriableError)(nil)
 
-func executePost[P any, R any](client *AlchemyClient, jsonP JsonParams[P]) (*AlchemyResponse[R], error) {
-	url, _ := client.getApiUrl()
-	body, err := json.Marshal(jsonP)
+// executePost sends jsonP through the client Transport and decodes the
+// answer. ctx bounds the whole call: the request and its retries are both
+// aborted as soon as ctx is done.
+// A JSON-RPC error is returned as an *AlchemyApiError, and kept in the
+// response Error field.
+func executePost[P any, R any](ctx context.Context, client *AlchemyClient, jsonP JsonParams[P]) (*AlchemyResponse[R], error) {
+	transport := client.transport()
+	if err := client.waitLimiter(ctx, transport, jsonP.Method); err != nil {
+		return &AlchemyResponse[R]{}, err
+	}
+	result, err := transport.Call(ctx, jsonP.Method, toInterfaces(jsonP.Params))
 	if err != nil {
-		method := fmt.Sprintf("executePost - %s", jsonP.Method)
-		return &AlchemyResponse[R]{}, &AlchemyClientError{method, err.Error()}
-	}
-
-	var resp *http.Response
-	var data AlchemyResponse[R]
-
-	data, err = retry.DoWithData(
-		func() (AlchemyResponse[R], error) {
-			resp, err = client.netClient.Post(
-				url,
-				"application/json",
-				bytes.NewBuffer(body))
-
-			if err == nil {
-				defer func() {
-					if err := resp.Body.Close(); err != nil {
-						panic(err)
-					}
-				}()
-
-				if resp.StatusCode != http.StatusOK {
-					err = fmt.Errorf("HTTP %d for: %s", resp.StatusCode, string(body))
-					if resp.StatusCode == http.StatusTooManyRequests {
-						// check Retry-After header if it contains seconds to wait for the next retry
-						if retryAfter, e := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 32); e == nil {
-							// the server returns 0 to inform that the operation cannot be retried
-							if retryAfter <= 0 {
-								return AlchemyResponse[R]{}, retry.Unrecoverable(err)
-							}
-							return AlchemyResponse[R]{}, &RetriableError{
-								Err:        err,
-								RetryAfter: time.Duration(retryAfter) * time.Second,
-							}
-						}
-						if retryAfter, e := strconv.ParseInt(resp.Header.Get("retryAfter"), 10, 32); e == nil {
-							// the server returns 0 to inform that the operation cannot be retried
-							if retryAfter <= 0 {
-								return AlchemyResponse[R]{}, retry.Unrecoverable(err)
-							}
-							return AlchemyResponse[R]{}, &RetriableError{
-								Err:        err,
-								RetryAfter: time.Duration(retryAfter) * time.Second,
-							}
-						}
-						return AlchemyResponse[R]{},err
-					}
+		var apiErr *AlchemyApiError
+		if errors.As(err, &apiErr) {
+			return &AlchemyResponse[R]{Id: jsonP.Id, Jsonrpc: "2.0", Error: *apiErr}, apiErr
+		}
+		return &AlchemyResponse[R]{}, err
+	}
+
+	data := AlchemyResponse[R]{Id: jsonP.Id, Jsonrpc: "2.0"}
+	if len(result) > 0 {
+		if err := json.Unmarshal(result, &data.Result); err != nil {
+			method := fmt.Sprintf("executePost - %s", jsonP.Method)
+			return &AlchemyResponse[R]{}, &AlchemyClientError{method, err.Error()}
+		}
+	}
+	return &data, nil
+}
+
+// toInterfaces converts typed params to the untyped slice a Transport takes.
+func toInterfaces[P any](params []P) []interface{} {
+	if params == nil {
+		return nil
+	}
+	out := make([]interface{}, len(params))
+	for i, p := range params {
+		out[i] = p
+	}
+	return out
+}
+
+// postWithRetry posts an already encoded json body to the Alchemy api and
+// decodes the answer into T, retrying failures as the client RetryPolicy
+// says. rpcError, when set, extracts the JSON-RPC error of an answer so
+// retryable error codes are retried too.
+// Every attempt is charged the compute units of methods by the client
+// RateLimiter, and logged with method, attempt number, http status,
+// Retry-After and latency.
+func postWithRetry[T any](ctx context.Context, client *AlchemyClient, method string, methods []string, body []byte, rpcError func(T) *AlchemyApiError) (T, error) {
+	url, _ := client.getApiUrl()
+	netClient := client.netClient
+	if netClient == nil {
+		netClient = http.DefaultClient
+	}
+	logger := client.log()
+	policy := client.retryPolicy()
+	start := time.Now()
+	attempt := uint(0)
+	var next time.Duration
+
+	return retry.DoWithData(
+		func() (T, error) {
+			attempt++
+			if client.limiter != nil {
+				if err := client.limiter.Wait(ctx, methods...); err != nil {
+					var empty T
+					return empty, retry.Unrecoverable(err)
+				}
+			}
+			sent := time.Now()
+			data, resp, err := postOnce[T](ctx, netClient, url, body, policy, rpcError)
+
+			attrs := []slog.Attr{
+				slog.String("method", method),
+				slog.Uint64("attempt", uint64(attempt)),
+				slog.Duration("latency", time.Since(sent)),
+			}
+			if resp != nil {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				if retryAfter := retryAfterHeader(resp); retryAfter != "" {
+					attrs = append(attrs, slog.String("retry_after", retryAfter))
 				}
-				err = json.NewDecoder(resp.Body).Decode(&data)
-				return data, err
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelWarn, "alchemy request failed", attrs...)
+			} else {
+				logger.LogAttrs(ctx, slog.LevelDebug, "alchemy request", attrs...)
 			}
 
-			return AlchemyResponse[R]{}, err
+			if err != nil && retry.IsRecoverable(err) {
+				if retriable, ok := err.(*RetriableError); ok {
+					// follow server recommendation
+					next = retriable.RetryAfter
+				} else {
+					next = policy.delay(attempt)
+				}
+				if policy.MaxElapsed > 0 && time.Since(start)+next > policy.MaxElapsed {
+					return data, retry.Unrecoverable(err)
+				}
+			}
+			return data, err
 		},
-		retry.Attempts(client.MaxRetry),
+		retry.Context(ctx),
+		retry.Attempts(policy.MaxAttempts),
 		retry.DelayType(func(n uint, err error, config *retry.Config) time.Duration {
-			fmt.Printf("[%d]Server fails with: %s\n", n, err.Error())
-			if retriable, ok := err.(*RetriableError); ok {
-				fmt.Printf("Client follows server recommendation to retry after %v\n", retriable.RetryAfter)
-				return retriable.RetryAfter
-			}
-			// apply a default exponential back off strategy
-			return retry.BackOffDelay(n, err, config)
+			logger.LogAttrs(ctx, slog.LevelInfo, "alchemy request retry",
+				slog.String("method", method),
+				slog.Uint64("attempt", uint64(attempt+1)),
+				slog.Duration("delay", next))
+			return next
 		}),
 	)
+}
+
+// postOnce makes a single attempt of postWithRetry and classifies its
+// failure: errors wrapped by retry.Unrecoverable are final, the others
+// are retried. The response is returned, body closed, whenever the server
+// answered.
+func postOnce[T any](ctx context.Context, netClient *http.Client, url string, body []byte, policy RetryPolicy, rpcError func(T) *AlchemyApiError) (T, *http.Response, error) {
+	var data T
+	var empty T
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return empty, nil, retry.Unrecoverable(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := netClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return empty, nil, retry.Unrecoverable(err)
+		}
+		return empty, nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			panic(err)
+		}
+	}()
 
-	return &data, err
+	if resp.StatusCode != http.StatusOK {
+		err = &HttpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+		if policy.retryableStatus(resp.StatusCode) {
+			if retryAfter, ok := policy.retryAfter(resp, time.Now()); ok {
+				if retryAfter < 0 {
+					return empty, resp, retry.Unrecoverable(err)
+				}
+				return empty, resp, &RetriableError{
+					Err:        err,
+					RetryAfter: retryAfter,
+				}
+			}
+			return empty, resp, err
+		}
+		// other statuses usually carry a JSON-RPC error worth decoding,
+		// an answer without one is no success either
+		if e := json.NewDecoder(resp.Body).Decode(&data); e != nil || rpcError == nil || rpcError(data) == nil {
+			return empty, resp, retry.Unrecoverable(err)
+		}
+	} else if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return empty, resp, err
+	}
+
+	if rpcError != nil {
+		if apiErr := rpcError(data); apiErr != nil && policy.retryableCode(apiErr) {
+			return empty, resp, apiErr
+		}
+	}
+	return data, resp, nil
+}
+
+// retryAfterHeader reads the standard Retry-After header, or the retryAfter
+// one some Alchemy answers carry instead.
+func retryAfterHeader(resp *http.Response) string {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		return v
+	}
+	return resp.Header.Get("retryAfter")
 }
 
 func (c *AlchemyClient) Close() {
-	c.netClient.CloseIdleConnections()
+	if c.netClient != nil {
+		c.netClient.CloseIdleConnections()
+	}
 }
 
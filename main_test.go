@@ -1,7 +1,9 @@
 package goalchemysdk
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,21 +13,163 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/nabetse00/go-alchemy-sdk/types"
 )
 
 var (
 	ALCHEMY_API_KEY_TEST string
+
+	// block and contract of the eth_getLogs queries
+	testLogsBlockHash = types.MustParseHash("0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb")
+	testLogsAddress   = types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c")
+
+	// log returned by the eth_getLogs queries
+	testLog = LogsResult{
+		Address: testLogsAddress,
+		Topics: []types.Hash{
+			types.MustParseHash("0xa6faee2246474597b6de7c76bf9a45d256737543cb0806e6e805b55b38c7663f"),
+			types.MustParseHash("0x000000000000000000000000000000000000000000000000000000000000012c")},
+		Data:             types.MustParseData("0x000000000000000000000000000000000000000000002d6077a3601d1b78000000000000000000000000000000000000000000000000b581c2cc130d1f1800000000000000000000000000000000000000000000000000000000000065692200"),
+		BlockNumber:      types.MustParseQuantity("0x9579fbd"),
+		TransactionHash:  types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3"),
+		TransactionIndex: types.Uint64Quantity(1),
+		BlockHash:        testLogsBlockHash,
+		LogIndex:         types.Uint64Quantity(2),
+		Removed:          false,
+	}
+
+	// beacons of the EIP1967 beacon proxies of testNode
+	testBeacon        = types.MustParseAddress("0x00000000000000000000000000000000000beac1")
+	testBeaconVariant = types.MustParseAddress("0x00000000000000000000000000000000000beac2")
+
+	// testNode serves the chain state queried by the offline tests, the
+	// proxies are those of the DetectProxyTarget tests.
+	testNode = fakeNode{
+		accounts: map[types.Address]fakeAccount{
+			// ARB token, an EIP1967 proxy
+			types.MustParseAddress("0x912ce59144191c1204e64559fe8253a0e49e6548"): {
+				code: testArbTokenCode,
+				storage: map[types.Hash]types.Hash{
+					{}: word("0x01"),
+					types.MustParseHash(EIP_1967_LOGIC_SLOT): word("0xc4ed0a9ea70d5bcc69f748547650d32cc219d882"),
+				},
+			},
+			// ENS public resolver
+			types.MustParseAddress("0x4976fb03c32e5b8cfe2b6ccb31c09ba78ebaba41"): {
+				calls: map[string]types.Hash{
+					"0x3b3b57debf074faa138b72c65adbdcfb329847e4f2c04bde7f7dd7fcad5a52d2f395a558": word("0x5555763613a12d8f3e73be831dff8598089d3dca"),
+				},
+			},
+			types.MustParseAddress("0xa81043fd06d57d140f6ad8c2913dbe87fdecdd5f"): {
+				code: "0x363d3d373d3d3d363d6f10fd301be3200e67978e3cc67c962f485af43d82803e903d91602757fd5bf3",
+			},
+			types.MustParseAddress("0x6d5d9b6ec51c15f45bfa4c460502403351d5b999"): {
+				code: "0x363d3d373d3d3d363d73210ff9ced719e9bf2444dbc3670bac99342126fa5af43d82803e903d91602b57fd5bf3",
+			},
+			types.MustParseAddress("0xa7aefead2f25972d80516628417ac46b3f2604af"): {
+				storage: map[types.Hash]types.Hash{
+					types.MustParseHash(EIP_1967_LOGIC_SLOT): word("0x4bd844f72a8edd323056130a86fc624d0dbcf5b0"),
+				},
+			},
+			types.MustParseAddress("0xdd4e2eb37268b047f55fc5caf22837f9ec08a881"): {
+				storage: map[types.Hash]types.Hash{
+					types.MustParseHash(EIP_1967_BEACON_SLOT): word(testBeacon.Hex()),
+				},
+			},
+			testBeacon: {
+				calls: map[string]types.Hash{
+					EIP_1167_BEACON_METHODS[0]: word("0xe5c048792dcf2e4a56000c8b6a47f21df22752d1"),
+				},
+			},
+			types.MustParseAddress("0x114f1388fab456c4ba31b1850b244eedcd024136"): {
+				storage: map[types.Hash]types.Hash{
+					types.MustParseHash(EIP_1967_BEACON_SLOT): word(testBeaconVariant.Hex()),
+				},
+			},
+			testBeaconVariant: {
+				calls: map[string]types.Hash{
+					EIP_1167_BEACON_METHODS[1]: word("0x36b799160cdc2d9809d108224d1967cc9b7d321c"),
+				},
+			},
+			// OpenZeppelin proxy, an EIP-897 delegate proxy too
+			types.MustParseAddress("0x8260b9ec6d472a34ad081297794d7cc00181360a"): {
+				storage: map[types.Hash]types.Hash{
+					types.MustParseHash(OPEN_ZEPPELIN_IMPLEMENTATION_SLOT): word("0xe4e4003afe3765aca8149a82fc064c0b125b9e5a"),
+				},
+				calls: map[string]types.Hash{
+					EIP_897_INTERFACE[0]: word("0xe4e4003afe3765aca8149a82fc064c0b125b9e5a"),
+				},
+			},
+			types.MustParseAddress("0x0da0c3e52c977ed3cbc641ff02dd271c3ed55afe"): {
+				calls: map[string]types.Hash{
+					GNOSIS_SAFE_PROXY_INTERFACE[0]: word("0xd9db270c1b5e3bd161e8c8503c55ceabee709552"),
+				},
+			},
+			types.MustParseAddress("0xfbdf75866904767de1caa8b64eb18a7562517f5a"): {
+				calls: map[string]types.Hash{
+					GNOSIS_SAFE_PROXY_INTERFACE[0]: word("0x3e5c63644e683549055b9be8653de26e0b4cd36e"),
+				},
+			},
+			types.MustParseAddress("0x4fa610dd115e790b8768a482fc366803534e9adc"): {
+				calls: map[string]types.Hash{
+					GNOSIS_SAFE_PROXY_INTERFACE[0]: word("0x3e5c63644e683549055b9be8653de26e0b4cd36e"),
+				},
+			},
+			types.MustParseAddress("0x3d9819210a31b4961b30ef54be2aed79b9c9cd3b"): {
+				calls: map[string]types.Hash{
+					COMPTROLLER_PROXY_INTERFACE[0]: word("0xbafe01ff935c7305907c33bf824352ee5979b526"),
+				},
+			},
+		},
+		logs: LogsResults{
+			testLog,
+			// same block, other contract
+			{
+				Address:         types.MustParseAddress("0x5957582f020301a2f732ad17a69ab2d8b2741241"),
+				BlockNumber:     types.MustParseQuantity("0x9579fbd"),
+				TransactionHash: types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3"),
+				BlockHash:       testLogsBlockHash,
+				LogIndex:        types.Uint64Quantity(3),
+			},
+		},
+		txns: map[types.Hash]string{
+			types.MustParseHash("0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178"): `{
+				"blockHash": "0x8fabe002a1d4f368ac26435cf998e6d3fe408843ae6d193b7be4f49931d9ea97",
+				"blockNumber": "0x4116290",
+				"hash": "0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178",
+				"accessList": [],
+				"chainId": "0xa4b1",
+				"from": "0xeba9a3b3664ce4c950cba62ed372c7815cbbfd75",
+				"gas": "0xe5398",
+				"gasPrice": "0x5f5e100",
+				"input": "0x287ad99a000000000000000000000000a6e249ffb81cf6f28ab021c3bd97620283c7335f000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000490a1",
+				"maxFeePerGas": "0x80befc0",
+				"maxPriorityFeePerGas": "0x0",
+				"nonce": "0x52",
+				"r": "0xea114e9bd51b1d0cd3bf21f8c12dfb3cb2c9e9421c693d00784861ba50222f45",
+				"s": "0x424eaebe2be7963bb739a45c1b318d8f0316e116ef31450f20e2fdacd9307ac7",
+				"to": "0x5957582f020301a2f732ad17a69ab2d8b2741241",
+				"transactionIndex": "0x1",
+				"type": "0x2",
+				"v": "0x1",
+				"value": "0x0"
+			}`,
+		},
+	}
 )
 
 // Helpers
-func initEnvs() {
+
+// initEnvs loads the api key of tests run against the live Alchemy api,
+// they are skipped when ALCHEMY_API_KEY is not set.
+func initEnvs(t *testing.T) {
 	err := godotenv.Load()
 	if err != nil {
 		fmt.Printf("Error loading .env file: %s.\n Are env vars setup manualy?\n", err)
 	}
 	ALCHEMY_API_KEY_TEST = os.Getenv("ALCHEMY_API_KEY")
 	if ALCHEMY_API_KEY_TEST == "" {
-		panic("Init env test: ALCHEMY_API_KEY_TEST empty")
+		t.Skip("Init env test: ALCHEMY_API_KEY empty, skipping live api test")
 	}
 	os.Setenv("APP_ENV", "test")
 }
@@ -168,7 +312,7 @@ func fakeRetryServerUnRecoverable2() *httptest.Server {
 // tests
 
 func TestAlchemyClient_getApiUrl(t *testing.T) {
-	initEnvs()
+	initWrongKeyEnvs()
 	tests := []struct {
 		name    string
 		c       *AlchemyClient
@@ -214,7 +358,6 @@ func TestAlchemyClient_getApiUrl(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost(t *testing.T) {
-	initEnvs()
 	type args struct {
 		j JsonParams[LogsParam]
 	}
@@ -227,13 +370,7 @@ func TestAlchemyClient_executePost(t *testing.T) {
 	}{
 		{
 			name: "test empty results",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: &AlchemyClient{Transport: testNode},
 			args: args{
 				j: JsonParams[LogsParam]{
 					Id:      1,
@@ -248,17 +385,11 @@ func TestAlchemyClient_executePost(t *testing.T) {
 				Result:  nil,
 				Error:   ErrorExpectedAtLeastOneArgument,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test with results",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: &AlchemyClient{Transport: testNode},
 			args: args{
 				j: JsonParams[LogsParam]{
 					Id:      1,
@@ -266,8 +397,8 @@ func TestAlchemyClient_executePost(t *testing.T) {
 					Method:  "eth_getLogs",
 					Params: []LogsParam{
 						{
-							BlockHash: "0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb",
-							Address:   "0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
+							BlockHash: &testLogsBlockHash,
+							Address:   &testLogsAddress,
 						},
 					},
 				},
@@ -277,16 +408,16 @@ func TestAlchemyClient_executePost(t *testing.T) {
 				Jsonrpc: "2.0",
 				Result: LogsResults{
 					{
-						Address: "0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
-						Topics: []string{
-							"0xa6faee2246474597b6de7c76bf9a45d256737543cb0806e6e805b55b38c7663f",
-							"0x000000000000000000000000000000000000000000000000000000000000012c"},
-						Data:             "0x000000000000000000000000000000000000000000002d6077a3601d1b78000000000000000000000000000000000000000000000000b581c2cc130d1f1800000000000000000000000000000000000000000000000000000000000065692200",
-						BlockNumber:      "0x9579fbd",
-						TransactionHash:  "0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3",
-						TransactionIndex: fmt.Sprintf("0x%x", 1),
-						BlockHash:        "0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb",
-						LogIndex:         fmt.Sprintf("0x%x", 2),
+						Address: testLogsAddress,
+						Topics: []types.Hash{
+							types.MustParseHash("0xa6faee2246474597b6de7c76bf9a45d256737543cb0806e6e805b55b38c7663f"),
+							types.MustParseHash("0x000000000000000000000000000000000000000000000000000000000000012c")},
+						Data:             types.MustParseData("0x000000000000000000000000000000000000000000002d6077a3601d1b78000000000000000000000000000000000000000000000000b581c2cc130d1f1800000000000000000000000000000000000000000000000000000000000065692200"),
+						BlockNumber:      types.MustParseQuantity("0x9579fbd"),
+						TransactionHash:  types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3"),
+						TransactionIndex: types.Uint64Quantity(1),
+						BlockHash:        testLogsBlockHash,
+						LogIndex:         types.Uint64Quantity(2),
 						Removed:          false,
 					},
 				},
@@ -296,7 +427,7 @@ func TestAlchemyClient_executePost(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[LogsParam, LogsResults](tt.c, tt.args.j)
+			got, err := executePost[LogsParam, LogsResults](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -310,7 +441,7 @@ func TestAlchemyClient_executePost(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost_wrongJson(t *testing.T) {
-	initEnvs()
+	initWrongKeyEnvs()
 	wrong := make([]interface{}, 1)
 	wrong[0] = make(chan int)
 	type args struct {
@@ -351,7 +482,7 @@ func TestAlchemyClient_executePost_wrongJson(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[interface{}, interface{}](tt.c, tt.args.j)
+			got, err := executePost[interface{}, interface{}](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -365,7 +496,7 @@ func TestAlchemyClient_executePost_wrongJson(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost_Retry_Recoverable(t *testing.T) {
-	initEnvs()
+	initWrongKeyEnvs()
 	ts := fakeRetryServerRecoverable()
 	defer ts.Close()
 
@@ -411,7 +542,7 @@ func TestAlchemyClient_executePost_Retry_Recoverable(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[interface{}, interface{}](tt.c, tt.args.j)
+			got, err := executePost[interface{}, interface{}](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -424,7 +555,7 @@ func TestAlchemyClient_executePost_Retry_Recoverable(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost_Retry_UnrecovarableAfter1(t *testing.T) {
-	initEnvs()
+	initWrongKeyEnvs()
 	ts := fakeRetryServerUnRecoverable1()
 	defer ts.Close()
 
@@ -470,7 +601,7 @@ func TestAlchemyClient_executePost_Retry_UnrecovarableAfter1(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[interface{}, interface{}](tt.c, tt.args.j)
+			got, err := executePost[interface{}, interface{}](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -483,7 +614,7 @@ func TestAlchemyClient_executePost_Retry_UnrecovarableAfter1(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost_Retry_UnrecovarableAfter2(t *testing.T) {
-	initEnvs()
+	initWrongKeyEnvs()
 	ts := fakeRetryServerUnRecoverable2()
 	defer ts.Close()
 
@@ -529,7 +660,7 @@ func TestAlchemyClient_executePost_Retry_UnrecovarableAfter2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[interface{}, interface{}](tt.c, tt.args.j)
+			got, err := executePost[interface{}, interface{}](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -542,7 +673,6 @@ func TestAlchemyClient_executePost_Retry_UnrecovarableAfter2(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost_wrong_method(t *testing.T) {
-	initEnvs()
 	type args struct {
 		j JsonParams[LogsParam]
 	}
@@ -555,13 +685,7 @@ func TestAlchemyClient_executePost_wrong_method(t *testing.T) {
 	}{
 		{
 			name: "test empty results",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: &AlchemyClient{Transport: testNode},
 			args: args{
 				j: JsonParams[LogsParam]{
 					Id:      1,
@@ -576,17 +700,11 @@ func TestAlchemyClient_executePost_wrong_method(t *testing.T) {
 				Result:  nil,
 				Error:   ErrorWrongMethod("eth_wrong_method"),
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "test with results",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: &AlchemyClient{Transport: testNode},
 			args: args{
 				j: JsonParams[LogsParam]{
 					Id:      1,
@@ -594,8 +712,8 @@ func TestAlchemyClient_executePost_wrong_method(t *testing.T) {
 					Method:  "eth_wrong_method",
 					Params: []LogsParam{
 						{
-							BlockHash: "0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb",
-							Address:   "0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
+							BlockHash: &testLogsBlockHash,
+							Address:   &testLogsAddress,
 						},
 					},
 				},
@@ -606,12 +724,12 @@ func TestAlchemyClient_executePost_wrong_method(t *testing.T) {
 				Result:  nil,
 				Error:   ErrorWrongMethod("eth_wrong_method"),
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[LogsParam, LogsResults](tt.c, tt.args.j)
+			got, err := executePost[LogsParam, LogsResults](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -625,7 +743,9 @@ func TestAlchemyClient_executePost_wrong_method(t *testing.T) {
 }
 
 func TestAlchemyClient_executePost_wrong_url(t *testing.T) {
-	initEnvs()
+	// nothing listens on the url of a closed server
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
 	type args struct {
 		j JsonParams[LogsParam]
 	}
@@ -639,9 +759,8 @@ func TestAlchemyClient_executePost_wrong_url(t *testing.T) {
 		{
 			name: "test wrong api url",
 			c: &AlchemyClient{
-				ApiKey:       ALCHEMY_API_KEY_TEST,
-				Network:      ARB_MAINNET,
-				BaseUrlApiV2: "wrong" + BASE_API_URL_V2,
+				ApiKey:       "key",
+				BaseUrlApiV2: ts.URL,
 				netClient: &http.Client{
 					Timeout: time.Second * 10,
 				},
@@ -663,13 +782,7 @@ func TestAlchemyClient_executePost_wrong_url(t *testing.T) {
 		},
 		{
 			name: "test with wrong method",
-			c: &AlchemyClient{
-				ApiKey:  ALCHEMY_API_KEY_TEST,
-				Network: ARB_MAINNET,
-				netClient: &http.Client{
-					Timeout: time.Second * 10,
-				},
-			},
+			c: &AlchemyClient{Transport: testNode},
 			args: args{
 				j: JsonParams[LogsParam]{
 					Id:      1,
@@ -677,8 +790,8 @@ func TestAlchemyClient_executePost_wrong_url(t *testing.T) {
 					Method:  "eth_wrong_method",
 					Params: []LogsParam{
 						{
-							BlockHash: "0x3ff6a0c14a272c9379838543735edf677fbe718df12ae52e921fc20f499f6feb",
-							Address:   "0x2cde9919e81b20b4b33dd562a48a84b54c48f00c",
+							BlockHash: &testLogsBlockHash,
+							Address:   &testLogsAddress,
 						},
 					},
 				},
@@ -689,12 +802,12 @@ func TestAlchemyClient_executePost_wrong_url(t *testing.T) {
 				Result:  nil,
 				Error:   ErrorWrongMethod("eth_wrong_method"),
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := executePost[LogsParam, LogsResults](tt.c, tt.args.j)
+			got, err := executePost[LogsParam, LogsResults](context.Background(), tt.c, tt.args.j)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AlchemyClient.executePost() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -708,7 +821,7 @@ func TestAlchemyClient_executePost_wrong_url(t *testing.T) {
 }
 
 func TestAlchemyClient_Init(t *testing.T) {
-	initEnvs()
+	initWrongKeyEnvs()
 	type args struct {
 		apiKey       string
 		network      Network
@@ -852,3 +965,66 @@ func TestAlchemyClient_Close(t *testing.T) {
 		})
 	}
 }
+
+func TestAlchemyClient_executePost_ContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{
+		ApiKey:       "key",
+		Network:      "",
+		BaseUrlApiV2: ts.URL,
+		MaxRetry:     10,
+		Delay:        1,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+	j := JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  "eth_fake",
+		Params:  make([]interface{}, 0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := executePost[interface{}, interface{}](ctx, c, j)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AlchemyClient.executePost() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("AlchemyClient.executePost() outlived its context by %v", elapsed)
+	}
+}
+
+func TestAlchemyClient_executePost_statusWithoutError(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{
+		ApiKey:       "key",
+		BaseUrlApiV2: ts.URL,
+		MaxRetry:     3,
+		netClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+	j := JsonParams[interface{}]{Id: 1, Jsonrpc: "2.0", Method: "eth_blockNumber"}
+	got, err := executePost[interface{}, string](context.Background(), c, j)
+	var statusErr *HttpStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("AlchemyClient.executePost() = %v, error = %v, want HTTP %d", got, err, http.StatusBadRequest)
+	}
+	if calls != 1 {
+		t.Errorf("AlchemyClient.executePost() sent %d requests, want 1", calls)
+	}
+}
@@ -1,7 +1,11 @@
 package goalchemysdk
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -22,6 +26,27 @@ const (
 	ASTAR_MAINNET Network = "astar-mainnet"
 )
 
+// chain ids of the networks, signed transactions are bound to them
+var NETWORK_CHAIN_IDS = map[Network]uint64{
+	ETH_MAINNET:   1,
+	ETH_GOERLI:    5,
+	ETH_SEPOLIA:   11155111,
+	MATIC_MAINNET: 137,
+	MATIC_MUMBAI:  80001,
+	OPT_MAINNET:   10,
+	OPT_GOERLI:    420,
+	OPT_KOVAN:     69,
+	ARB_MAINNET:   42161,
+	ARB_GOERLI:    421613,
+	ASTAR_MAINNET: 592,
+}
+
+// ChainId returns the chain id of the network, false when unknown.
+func (n Network) ChainId() (uint64, bool) {
+	id, ok := NETWORK_CHAIN_IDS[n]
+	return id, ok
+}
+
 type BlockTag string
 
 const (
@@ -41,8 +66,9 @@ type JsonParams[P any] struct {
 }
 
 type AlchemyApiError struct {
-	Code    int    `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
+	Code    int       `json:"code,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Data    ErrorData `json:"data,omitempty"`
 }
 
 // custom error type
@@ -50,6 +76,85 @@ func (ae *AlchemyApiError) Error() string {
 	return fmt.Sprintf("Alchemy Api error code=%d, message=%s", ae.Code, ae.Message)
 }
 
+func (ae *AlchemyApiError) isExecutionReverted() bool {
+	switch ae.Code {
+	case CODE_EXECUTION_REVERTED:
+		return true
+	case CODE_SERVER_ERROR:
+		return strings.Contains(strings.ToLower(ae.Message), "execution reverted")
+	}
+	return false
+}
+
+// ErrorData keeps the raw json of a JSON-RPC error data field, as a string
+// so AlchemyApiError stays comparable.
+type ErrorData string
+
+func (d ErrorData) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return []byte(d), nil
+}
+
+func (d *ErrorData) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*d = ""
+		return nil
+	}
+	*d = ErrorData(b)
+	return nil
+}
+
+// Hex returns the data when it is a hex string, as revert payloads are.
+func (d ErrorData) Hex() (string, bool) {
+	var hex string
+	if err := json.Unmarshal([]byte(d), &hex); err != nil || !strings.HasPrefix(hex, "0x") {
+		return "", false
+	}
+	return hex, true
+}
+
+// IsExecutionReverted tells if err is a JSON-RPC error reporting a
+// reverted call or transaction.
+func IsExecutionReverted(err error) bool {
+	var apiErr *AlchemyApiError
+	return errors.As(err, &apiErr) && apiErr.isExecutionReverted()
+}
+
+// IsRateLimited tells if err comes from a rate limit, enforced by Alchemy
+// or by the client RateLimiter. An eth_getLogs query returning too many
+// logs is not, though it shares the limit exceeded code.
+func IsRateLimited(err error) bool {
+	if errors.Is(err, ErrRateLimitExceeded) {
+		return true
+	}
+	var statusErr *HttpStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	var apiErr *AlchemyApiError
+	return errors.As(err, &apiErr) && (apiErr.Code == CODE_RATE_LIMITED || apiErr.Code == CODE_LIMIT_EXCEEDED && !apiErr.logsRangeExceeded())
+}
+
+// IsInvalidArgument tells if err is a JSON-RPC error rejecting the
+// request params.
+func IsInvalidArgument(err error) bool {
+	var apiErr *AlchemyApiError
+	return errors.As(err, &apiErr) && apiErr.Code == CODE_INVALID_PARAMS
+}
+
+// HttpStatusError is returned when Alchemy answers with an unexpected http
+// status.
+type HttpStatusError struct {
+	StatusCode int
+	Body       string // request body
+}
+
+func (e *HttpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d for: %s", e.StatusCode, e.Body)
+}
+
 type AlchemyResponse[R any] struct {
 	Id      uint            `json:"id"`
 	Jsonrpc string          `json:"jsonrpc"`
@@ -1,6 +1,12 @@
 package goalchemysdk
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestAlchemyApiError_Error(t *testing.T) {
 	tests := []struct {
@@ -22,3 +28,106 @@ func TestAlchemyApiError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorData_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    AlchemyApiError
+		wantHex string
+		wantOk  bool
+	}{
+		{
+			name:    "revert payload",
+			payload: `{"code":3,"message":"execution reverted","data":"0x08c379a0"}`,
+			want:    AlchemyApiError{Code: 3, Message: "execution reverted", Data: `"0x08c379a0"`},
+			wantHex: "0x08c379a0",
+			wantOk:  true,
+		},
+		{
+			name:    "object data",
+			payload: `{"code":-32000,"message":"header not found","data":{"foo":1}}`,
+			want:    AlchemyApiError{Code: -32000, Message: "header not found", Data: `{"foo":1}`},
+		},
+		{
+			name:    "no data",
+			payload: `{"code":-32602,"message":"invalid argument"}`,
+			want:    AlchemyApiError{Code: -32602, Message: "invalid argument"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got AlchemyApiError
+			if err := json.Unmarshal([]byte(tt.payload), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("json.Unmarshal() = %v, want %v", got, tt.want)
+			}
+			hex, ok := got.Data.Hex()
+			if hex != tt.wantHex || ok != tt.wantOk {
+				t.Errorf("ErrorData.Hex() = %v, %v, want %v, %v", hex, ok, tt.wantHex, tt.wantOk)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var back AlchemyApiError
+			if err := json.Unmarshal(b, &back); err != nil || back != got {
+				t.Errorf("json round trip = %v, %v, want %v", back, err, got)
+			}
+		})
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	reverted := &AlchemyApiError{Code: CODE_EXECUTION_REVERTED, Message: "execution reverted"}
+	tests := []struct {
+		name            string
+		err             error
+		wantReverted    bool
+		wantRateLimited bool
+		wantInvalidArg  bool
+	}{
+		{"reverted", reverted, true, false, false},
+		{"wrapped reverted", fmt.Errorf("eth_call: %w", reverted), true, false, false},
+		{"reverted server error", &AlchemyApiError{Code: CODE_SERVER_ERROR, Message: "execution reverted: paused"}, true, false, false},
+		{"rate limit code", &AlchemyApiError{Code: CODE_RATE_LIMITED}, false, true, false},
+		{"limit exceeded code", &AlchemyApiError{Code: CODE_LIMIT_EXCEEDED}, false, true, false},
+		{"logs range exceeded", &AlchemyApiError{Code: CODE_LIMIT_EXCEEDED, Message: "query returned more than 10000 results. Try with this block range [0x0, 0x1d3e]."}, false, false, false},
+		{"rate limit status", &HttpStatusError{StatusCode: 429}, false, true, false},
+		{"client limiter", fmt.Errorf("%w for eth_call", ErrRateLimitExceeded), false, true, false},
+		{"invalid argument", &ErrorTooShortAddress, false, false, true},
+		{"other", errors.New("boom"), false, false, false},
+		{"nil", nil, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsExecutionReverted(tt.err); got != tt.wantReverted {
+				t.Errorf("IsExecutionReverted() = %v, want %v", got, tt.wantReverted)
+			}
+			if got := IsRateLimited(tt.err); got != tt.wantRateLimited {
+				t.Errorf("IsRateLimited() = %v, want %v", got, tt.wantRateLimited)
+			}
+			if got := IsInvalidArgument(tt.err); got != tt.wantInvalidArg {
+				t.Errorf("IsInvalidArgument() = %v, want %v", got, tt.wantInvalidArg)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_Eth_call_ApiError(t *testing.T) {
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			return nil, &AlchemyApiError{Code: 3, Message: "execution reverted", Data: `"0x4e487b71"`}
+		}),
+	}
+	_, err := c.Eth_call(context.Background(), CallTxn{}, LATEST)
+	var apiErr *AlchemyApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("AlchemyClient.Eth_call() error = %v, want *AlchemyApiError", err)
+	}
+	if hex, _ := apiErr.Data.Hex(); hex != "0x4e487b71" {
+		t.Errorf("AlchemyClient.Eth_call() error data = %v, want 0x4e487b71", hex)
+	}
+}
@@ -0,0 +1,133 @@
+package goalchemysdk
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const TIMEOUT_DEFAULT = 10 * time.Second
+
+// Option configures an AlchemyClient built by NewClient.
+type Option func(*AlchemyClient)
+
+// WithHTTPClient sets the http client requests are posted with.
+// The client is copied, so a later WithTimeout does not modify it.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *AlchemyClient) {
+		if hc != nil {
+			copied := *hc
+			c.netClient = &copied
+		}
+	}
+}
+
+// WithTimeout sets the timeout of each http request, TIMEOUT_DEFAULT is
+// used otherwise.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *AlchemyClient) {
+		if c.netClient == nil {
+			c.netClient = &http.Client{}
+		}
+		c.netClient.Timeout = timeout
+	}
+}
+
+// WithBaseURL replaces BASE_API_URL_V2, the part of the api url placed
+// between the network and the api key.
+func WithBaseURL(baseUrlApiV2 string) Option {
+	return func(c *AlchemyClient) {
+		c.BaseUrlApiV2 = baseUrlApiV2
+	}
+}
+
+// WithRetry sets the number of attempts made for a request and the delay,
+// in milliseconds, before the first retry; later ones back off from it.
+func WithRetry(maxRetry uint, delay uint) Option {
+	return func(c *AlchemyClient) {
+		c.MaxRetry = maxRetry
+		c.Delay = delay
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy, MaxRetry is then
+// ignored in favour of policy.MaxAttempts.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *AlchemyClient) {
+		c.policy = &policy
+	}
+}
+
+// WithRateLimiter makes every request wait for its compute units in l,
+// and every retry of it over https.
+// The same limiter can be given to many clients sharing an api key.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *AlchemyClient) {
+		c.limiter = l
+	}
+}
+
+// WithTransport makes the client send its requests through t instead of
+// posting them over https.
+func WithTransport(t Transport) Option {
+	return func(c *AlchemyClient) {
+		c.Transport = t
+	}
+}
+
+// WithCustomErrors makes reverted calls decode the custom errors in errs,
+// see ParseCustomErrors. It can be given many times to merge sets.
+func WithCustomErrors(errs CustomErrors) Option {
+	return func(c *AlchemyClient) {
+		if c.customErrors == nil {
+			c.customErrors = CustomErrors{}
+		}
+		for selector, e := range errs {
+			c.customErrors[selector] = e
+		}
+	}
+}
+
+// WithPollInterval sets how often WaitForTransaction polls,
+// POLL_INTERVAL_DEFAULT is used otherwise.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *AlchemyClient) {
+		c.pollInterval = interval
+	}
+}
+
+// WithLogger sets the logger request attempts and retries are reported to.
+// Clients are silent by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *AlchemyClient) {
+		c.logger = logger
+	}
+}
+
+// NewClient returns a client ready to query network with apiKey.
+// Unset settings get the same defaults as Init.
+func NewClient(apiKey string, network Network, opts ...Option) (*AlchemyClient, error) {
+	c := &AlchemyClient{
+		ApiKey:  apiKey,
+		Network: network,
+	}
+	if c.ApiKey == "" {
+		return nil, &AlchemyClientError{"NewClient", "Empty Alchemy key"}
+	}
+	if c.Network == "" {
+		return nil, &AlchemyClientError{"NewClient", "Empty Alchemy Network"}
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.netClient == nil {
+		c.netClient = &http.Client{
+			Timeout: TIMEOUT_DEFAULT,
+		}
+	}
+	// fills BaseUrlApiV2, MaxRetry and Delay defaults
+	if _, err := c.getApiUrl(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,86 @@
+package goalchemysdk
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	custom := &http.Client{Timeout: time.Second}
+	tr := fakeTransport(nil)
+	tests := []struct {
+		name    string
+		apiKey  string
+		network Network
+		opts    []Option
+		want    *AlchemyClient
+		wantErr bool
+	}{
+		{
+			name:    "empty key",
+			apiKey:  "",
+			network: ETH_MAINNET,
+			wantErr: true,
+		},
+		{
+			name:    "empty network",
+			apiKey:  "key",
+			network: "",
+			wantErr: true,
+		},
+		{
+			name:    "defaults",
+			apiKey:  "key",
+			network: ETH_MAINNET,
+			want: &AlchemyClient{
+				ApiKey:       "key",
+				Network:      ETH_MAINNET,
+				MaxRetry:     MAX_RETRY_DEFAULT,
+				Delay:        DELAY_DEFAULT,
+				BaseUrlApiV2: BASE_API_URL_V2,
+				netClient:    &http.Client{Timeout: TIMEOUT_DEFAULT},
+			},
+		},
+		{
+			name:    "custom values",
+			apiKey:  "key",
+			network: ARB_MAINNET,
+			opts: []Option{
+				WithHTTPClient(custom),
+				WithTimeout(5 * time.Second),
+				WithBaseURL("someurl.here.com"),
+				WithRetry(10, 2),
+			},
+			want: &AlchemyClient{
+				ApiKey:       "key",
+				Network:      ARB_MAINNET,
+				MaxRetry:     10,
+				Delay:        2,
+				BaseUrlApiV2: "someurl.here.com",
+				netClient:    &http.Client{Timeout: 5 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewClient(tt.apiKey, tt.network, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewClient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if custom.Timeout != time.Second {
+		t.Errorf("NewClient() modified the http client given to WithHTTPClient")
+	}
+
+	c, err := NewClient("key", ETH_MAINNET, WithTransport(tr))
+	if err != nil || c.Transport == nil {
+		t.Errorf("NewClient() with transport = %v, %v", c, err)
+	}
+}
@@ -0,0 +1,225 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+var (
+	// EMPTY_ROOT_HASH is the root of an empty trie, the storage root of
+	// accounts without storage.
+	EMPTY_ROOT_HASH = types.MustParseHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+	// EMPTY_CODE_HASH is the code hash of accounts without code.
+	EMPTY_CODE_HASH = types.MustParseHash("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+)
+
+// ErrInvalidProof is wrapped by the errors of AccountProof.Verify.
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
+// Verify checks the account and every storage slot of p against
+// stateRoot. stateRoot must come from a source trusted independently of
+// the node which gave the proof, such as a light client, otherwise the
+// check proves nothing.
+// An account or slot proven absent must be given as empty or zero.
+func (p *AccountProof) Verify(stateRoot types.Hash) error {
+	value, err := verifyTrieProof(stateRoot, keccak256(p.Address.Bytes()), p.AccountProof)
+	if err != nil {
+		return fmt.Errorf("%w: account %s: %v", ErrInvalidProof, p.Address, err)
+	}
+	if err := p.checkAccount(value); err != nil {
+		return fmt.Errorf("%w: account %s: %v", ErrInvalidProof, p.Address, err)
+	}
+	for _, sp := range p.StorageProof {
+		if err := sp.Verify(p.StorageHash); err != nil {
+			return fmt.Errorf("account %s: %w", p.Address, err)
+		}
+	}
+	return nil
+}
+
+// checkAccount compares p with the account leaf value of the state trie.
+func (p *AccountProof) checkAccount(value []byte) error {
+	if value == nil {
+		if orZero(p.Nonce).Sign() != 0 || orZero(p.Balance).Sign() != 0 || p.StorageHash != EMPTY_ROOT_HASH || p.CodeHash != EMPTY_CODE_HASH {
+			return errors.New("proven absent but given as not empty")
+		}
+		return nil
+	}
+	items, err := rlpListItems(value)
+	if err != nil {
+		return err
+	}
+	if len(items) != 4 {
+		return fmt.Errorf("account of %d fields, want 4", len(items))
+	}
+	fields := make([][]byte, 4)
+	for i, item := range items {
+		if fields[i], err = rlpBytes(item); err != nil {
+			return err
+		}
+	}
+	switch {
+	case new(big.Int).SetBytes(fields[0]).Cmp(orZero(p.Nonce)) != 0:
+		return fmt.Errorf("nonce is 0x%x, not %s", fields[0], p.Nonce.Hex())
+	case new(big.Int).SetBytes(fields[1]).Cmp(orZero(p.Balance)) != 0:
+		return fmt.Errorf("balance is 0x%x, not %s", fields[1], p.Balance.Hex())
+	case !bytes.Equal(fields[2], p.StorageHash.Bytes()):
+		return fmt.Errorf("storage hash is 0x%x, not %s", fields[2], p.StorageHash)
+	case !bytes.Equal(fields[3], p.CodeHash.Bytes()):
+		return fmt.Errorf("code hash is 0x%x, not %s", fields[3], p.CodeHash)
+	}
+	return nil
+}
+
+// Verify checks the slot value of sp against the storage root of its
+// account. Use AccountProof.Verify to check the storage root too.
+func (sp *StorageProof) Verify(storageRoot types.Hash) error {
+	if sp.Key == nil || sp.Key.Big().BitLen() > 256 {
+		return fmt.Errorf("%w: invalid storage key %s", ErrInvalidProof, sp.Key.Hex())
+	}
+	key := sp.Key.Big().FillBytes(make([]byte, 32))
+	value, err := verifyTrieProof(storageRoot, keccak256(key), sp.Proof)
+	if err != nil {
+		return fmt.Errorf("%w: slot %s: %v", ErrInvalidProof, sp.Key.Hex(), err)
+	}
+	proven := new(big.Int)
+	if value != nil {
+		content, err := rlpBytes(value)
+		if err != nil {
+			return fmt.Errorf("%w: slot %s: %v", ErrInvalidProof, sp.Key.Hex(), err)
+		}
+		proven.SetBytes(content)
+	}
+	if proven.Cmp(orZero(sp.Value)) != 0 {
+		return fmt.Errorf("%w: slot %s is 0x%x, not %s", ErrInvalidProof, sp.Key.Hex(), proven, sp.Value.Hex())
+	}
+	return nil
+}
+
+// orZero reads an unset quantity as zero.
+func orZero(q *types.Quantity) *big.Int {
+	if q == nil {
+		return new(big.Int)
+	}
+	return q.Big()
+}
+
+// verifyTrieProof walks the Merkle Patricia trie of root along key through
+// the nodes of proof, root node first. It returns the value stored at key,
+// nil when the proof shows there is none.
+func verifyTrieProof(root types.Hash, key []byte, proof []types.Data) ([]byte, error) {
+	path := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		path = append(path, b>>4, b&0x0f)
+	}
+	if root == EMPTY_ROOT_HASH && len(proof) == 0 {
+		return nil, nil
+	}
+	want := root.Bytes()
+	for i := 0; ; i++ {
+		if i >= len(proof) {
+			return nil, fmt.Errorf("proof ends after %d nodes", len(proof))
+		}
+		if !bytes.Equal(keccak256(proof[i]), want) {
+			return nil, fmt.Errorf("node %d does not match its hash", i)
+		}
+		node := []byte(proof[i])
+		// nodes shorter than a hash are embedded in their parent
+		for {
+			child, value, done, err := trieStep(node, &path)
+			if err != nil {
+				return nil, fmt.Errorf("node %d: %w", i, err)
+			}
+			if done {
+				if i != len(proof)-1 {
+					return nil, fmt.Errorf("%d unused proof nodes", len(proof)-1-i)
+				}
+				return value, nil
+			}
+			content, isList, _, _ := rlpSplit(child)
+			if isList {
+				node = child
+				continue
+			}
+			if len(content) == 0 {
+				// empty branch slot, the key is absent
+				if i != len(proof)-1 {
+					return nil, fmt.Errorf("%d unused proof nodes", len(proof)-1-i)
+				}
+				return nil, nil
+			}
+			if len(content) != 32 {
+				return nil, fmt.Errorf("child reference of %d bytes", len(content))
+			}
+			want = content
+			break
+		}
+	}
+}
+
+// trieStep follows path through node. It either returns the encoded child
+// reference to follow next, consuming path, or the value found at path
+// with done set.
+func trieStep(node []byte, path *[]byte) (child []byte, value []byte, done bool, err error) {
+	items, err := rlpListItems(node)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	switch len(items) {
+	case 17:
+		if len(*path) == 0 {
+			value, err := rlpBytes(items[16])
+			if err != nil || len(value) == 0 {
+				return nil, nil, true, err
+			}
+			return nil, value, true, nil
+		}
+		child, *path = items[(*path)[0]], (*path)[1:]
+		return child, nil, false, nil
+	case 2:
+		encoded, err := rlpBytes(items[0])
+		if err != nil {
+			return nil, nil, false, err
+		}
+		nibbles, leaf, err := compactToNibbles(encoded)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if leaf {
+			if !bytes.Equal(nibbles, *path) {
+				return nil, nil, true, nil
+			}
+			value, err := rlpBytes(items[1])
+			return nil, value, true, err
+		}
+		if !bytes.HasPrefix(*path, nibbles) {
+			return nil, nil, true, nil
+		}
+		*path = (*path)[len(nibbles):]
+		return items[1], nil, false, nil
+	}
+	return nil, nil, false, fmt.Errorf("trie node of %d items", len(items))
+}
+
+// compactToNibbles decodes the hex prefix encoded path of a leaf or
+// extension node.
+func compactToNibbles(b []byte) (nibbles []byte, leaf bool, err error) {
+	if len(b) == 0 {
+		return nil, false, errors.New("empty node path")
+	}
+	flag := b[0] >> 4
+	if flag > 3 {
+		return nil, false, fmt.Errorf("invalid node path flag %d", flag)
+	}
+	if flag&1 == 1 {
+		nibbles = append(nibbles, b[0]&0x0f)
+	}
+	for _, d := range b[1:] {
+		nibbles = append(nibbles, d>>4, d&0x0f)
+	}
+	return nibbles, flag&2 == 2, nil
+}
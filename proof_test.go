@@ -0,0 +1,277 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// testTrie is a Merkle Patricia trie built from scratch, to give proofs
+// to the verifier.
+type testTrie map[string][]byte
+
+func nibbles(key []byte) []byte {
+	path := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		path = append(path, b>>4, b&0x0f)
+	}
+	return path
+}
+
+func compactPath(path []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(path)%2 == 1 {
+		out := []byte{(flag+1)<<4 | path[0]}
+		for i := 1; i < len(path); i += 2 {
+			out = append(out, path[i]<<4|path[i+1])
+		}
+		return out
+	}
+	out := []byte{flag << 4}
+	for i := 0; i < len(path); i += 2 {
+		out = append(out, path[i]<<4|path[i+1])
+	}
+	return out
+}
+
+// proof returns the trie root and the proof of key.
+func (tr testTrie) proof(key []byte) (types.Hash, []types.Data) {
+	var paths [][]byte
+	for k := range tr {
+		paths = append(paths, nibbles([]byte(k)))
+	}
+	sort.Slice(paths, func(i, j int) bool { return bytes.Compare(paths[i], paths[j]) < 0 })
+	root, proof := tr.node(paths, 0, nibbles(key))
+	return types.BytesToHash(keccak256(root)), append([]types.Data{root}, proof...)
+}
+
+// node encodes the node holding paths below depth, with the proof of
+// target below that node.
+func (tr testTrie) node(paths [][]byte, depth int, target []byte) ([]byte, []types.Data) {
+	value := func(path []byte) []byte {
+		key := make([]byte, len(path)/2)
+		for i := range key {
+			key[i] = path[2*i]<<4 | path[2*i+1]
+		}
+		return tr[string(key)]
+	}
+	if len(paths) == 1 {
+		return rlpList(rlpString(compactPath(paths[0][depth:], true)), rlpString(value(paths[0]))), nil
+	}
+	prefix := len(paths[0]) - depth
+	for _, p := range paths[1:] {
+		n := 0
+		for n < prefix && depth+n < len(p) && p[depth+n] == paths[0][depth+n] {
+			n++
+		}
+		prefix = n
+	}
+	if prefix > 0 {
+		onPath := bytes.HasPrefix(target, paths[0][:depth+prefix])
+		ref, proof := tr.ref(paths, depth+prefix, target, onPath)
+		return rlpList(rlpString(compactPath(paths[0][depth:depth+prefix], false)), ref), proof
+	}
+	items := make([][]byte, 17)
+	items[16] = rlpString(nil)
+	var proof []types.Data
+	for nibble := byte(0); nibble < 16; nibble++ {
+		var group [][]byte
+		for _, p := range paths {
+			if len(p) > depth && p[depth] == nibble {
+				group = append(group, p)
+			}
+		}
+		if len(group) == 0 {
+			items[nibble] = rlpString(nil)
+			continue
+		}
+		onPath := len(target) > depth && target[depth] == nibble
+		var childProof []types.Data
+		items[nibble], childProof = tr.ref(group, depth+1, target, onPath)
+		if onPath {
+			proof = childProof
+		}
+	}
+	for _, p := range paths {
+		if len(p) == depth {
+			items[16] = rlpString(value(p))
+		}
+	}
+	return rlpList(items...), proof
+}
+
+// ref returns the reference to the child node of paths, the node itself
+// when shorter than a hash.
+func (tr testTrie) ref(paths [][]byte, depth int, target []byte, onPath bool) ([]byte, []types.Data) {
+	child, proof := tr.node(paths, depth, target)
+	if !onPath {
+		proof = nil
+	}
+	if len(child) < 32 {
+		return child, proof
+	}
+	if onPath {
+		proof = append([]types.Data{child}, proof...)
+	}
+	return rlpString(keccak256(child)), proof
+}
+
+func TestEmptyHashes(t *testing.T) {
+	if got := types.BytesToHash(keccak256(rlpString(nil))); got != EMPTY_ROOT_HASH {
+		t.Errorf("EMPTY_ROOT_HASH = %s, want %s", EMPTY_ROOT_HASH, got)
+	}
+	if got := types.BytesToHash(keccak256()); got != EMPTY_CODE_HASH {
+		t.Errorf("EMPTY_CODE_HASH = %s, want %s", EMPTY_CODE_HASH, got)
+	}
+}
+
+func Test_verifyTrieProof(t *testing.T) {
+	// roots of the ethereum/tests trie vectors
+	vectors := []struct {
+		name string
+		trie testTrie
+		root string
+	}{
+		{
+			name: "dogs",
+			trie: testTrie{"doe": []byte("reindeer"), "dog": []byte("puppy"), "dogglesworth": []byte("cat")},
+			root: "0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			name: "puppy",
+			trie: testTrie{"do": []byte("verb"), "horse": []byte("stallion"), "doge": []byte("coin"), "dog": []byte("puppy")},
+			root: "0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		},
+	}
+	for _, v := range vectors {
+		for _, key := range []string{"do", "dog", "doe", "doge", "dogglesworth", "horse", "dot", "cat", "doggy"} {
+			t.Run(v.name+" "+key, func(t *testing.T) {
+				root, proof := v.trie.proof([]byte(key))
+				if root != types.MustParseHash(v.root) {
+					t.Fatalf("test trie root = %s, want %s", root, v.root)
+				}
+				got, err := verifyTrieProof(root, []byte(key), proof)
+				if err != nil {
+					t.Fatalf("verifyTrieProof() error = %v", err)
+				}
+				if want := v.trie[key]; !bytes.Equal(got, want) {
+					t.Errorf("verifyTrieProof() = %q, want %q", got, want)
+				}
+			})
+		}
+	}
+
+	trie := vectors[0].trie
+	root, proof := trie.proof([]byte("dog"))
+	tampered := append([]types.Data{}, proof...)
+	tampered[len(tampered)-1] = append(types.Data{}, proof[len(proof)-1]...)
+	tampered[len(tampered)-1][len(tampered[len(tampered)-1])-1] ^= 1
+	invalid := []struct {
+		name  string
+		root  types.Hash
+		proof []types.Data
+	}{
+		{"wrong root", EMPTY_CODE_HASH, proof},
+		{"tampered node", root, tampered},
+		{"truncated", root, proof[:len(proof)-1]},
+		{"unused nodes", root, append(append([]types.Data{}, proof...), proof[0])},
+		{"empty", root, nil},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifyTrieProof(tt.root, []byte("dog"), tt.proof); err == nil {
+				t.Errorf("verifyTrieProof() error = nil")
+			}
+		})
+	}
+}
+
+func TestAccountProof_Verify(t *testing.T) {
+	address := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	other := types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c")
+	slot := func(n int64) []byte { return big.NewInt(n).FillBytes(make([]byte, 32)) }
+
+	storage := testTrie{}
+	for n := int64(0); n < 20; n++ {
+		storage[string(keccak256(slot(n)))] = rlpUint(big.NewInt(1000 + n))
+	}
+	storageRoot, _ := storage.proof(nil)
+	codeHash := types.BytesToHash(keccak256([]byte{0x60, 0x00}))
+	account := func(nonce, balance int64, storageRoot, codeHash types.Hash) []byte {
+		return rlpList(rlpUint(big.NewInt(nonce)), rlpUint(big.NewInt(balance)), rlpString(storageRoot.Bytes()), rlpString(codeHash.Bytes()))
+	}
+	state := testTrie{
+		string(keccak256(address.Bytes())): account(3, 5e18, storageRoot, codeHash),
+		string(keccak256(other.Bytes())):   account(1, 7, EMPTY_ROOT_HASH, EMPTY_CODE_HASH),
+	}
+	stateRoot, accountProof := state.proof(keccak256(address.Bytes()))
+	storageProof := func(n int64, value int64) StorageProof {
+		_, proof := storage.proof(keccak256(slot(n)))
+		return StorageProof{Key: types.Uint64Quantity(uint64(n)), Value: types.Uint64Quantity(uint64(value)), Proof: proof}
+	}
+	valid := func() AccountProof {
+		return AccountProof{
+			Address:      address,
+			AccountProof: accountProof,
+			Balance:      types.NewQuantity(big.NewInt(5e18)),
+			CodeHash:     codeHash,
+			Nonce:        types.Uint64Quantity(3),
+			StorageHash:  storageRoot,
+			StorageProof: []StorageProof{storageProof(7, 1007), storageProof(19, 1019), storageProof(42, 0)},
+		}
+	}
+	absent := types.MustParseAddress("0x0000000000000000000000000000000000000001")
+	_, absentProof := state.proof(keccak256(absent.Bytes()))
+
+	tests := []struct {
+		name    string
+		edit    func(p *AccountProof)
+		wantErr bool
+	}{
+		{"valid", func(p *AccountProof) {}, false},
+		{"wrong balance", func(p *AccountProof) { p.Balance = types.Uint64Quantity(1) }, true},
+		{"wrong nonce", func(p *AccountProof) { p.Nonce = types.Uint64Quantity(4) }, true},
+		{"wrong code hash", func(p *AccountProof) { p.CodeHash = EMPTY_CODE_HASH }, true},
+		{"wrong storage hash", func(p *AccountProof) { p.StorageHash = EMPTY_ROOT_HASH }, true},
+		{"wrong slot value", func(p *AccountProof) { p.StorageProof[1].Value = types.Uint64Quantity(1) }, true},
+		{"absent slot given set", func(p *AccountProof) { p.StorageProof[2].Value = types.Uint64Quantity(1) }, true},
+		{"proof of another slot", func(p *AccountProof) { p.StorageProof[0].Key = types.Uint64Quantity(8) }, true},
+		{"proof of another account", func(p *AccountProof) { p.Address = other }, true},
+		{
+			name: "absent account",
+			edit: func(p *AccountProof) {
+				*p = AccountProof{Address: absent, AccountProof: absentProof, Nonce: types.Uint64Quantity(0), Balance: types.Uint64Quantity(0),
+					StorageHash: EMPTY_ROOT_HASH, CodeHash: EMPTY_CODE_HASH,
+					StorageProof: []StorageProof{{Key: types.Uint64Quantity(0), Value: types.Uint64Quantity(0)}}}
+			},
+		},
+		{
+			name: "absent account given funded",
+			edit: func(p *AccountProof) {
+				*p = AccountProof{Address: absent, AccountProof: absentProof, Balance: types.Uint64Quantity(1),
+					StorageHash: EMPTY_ROOT_HASH, CodeHash: EMPTY_CODE_HASH}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.edit(&p)
+			err := p.Verify(stateRoot)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AccountProof.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("AccountProof.Verify() error = %v, want ErrInvalidProof", err)
+			}
+		})
+	}
+}
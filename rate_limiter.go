@@ -0,0 +1,157 @@
+package goalchemysdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// COMPUTE_UNITS_DEFAULT is charged for methods missing from the cost table.
+const COMPUTE_UNITS_DEFAULT = 26
+
+// METHOD_COMPUTE_UNITS is the Alchemy compute unit (CU) cost of each method.
+var METHOD_COMPUTE_UNITS = map[string]uint{
+	"eth_blockNumber":                10,
+	"eth_call":                       26,
+	"eth_chainId":                    0,
+	"eth_estimateGas":                87,
+	"eth_feeHistory":                 10,
+	"eth_gasPrice":                   19,
+	"eth_getBalance":                 19,
+	"eth_getBlockByHash":             21,
+	"eth_getBlockByNumber":           16,
+	"eth_getCode":                    26,
+	"eth_getLogs":                    75,
+	"eth_getProof":                   21,
+	"eth_getStorageAt":               17,
+	"eth_getTransactionByHash":       17,
+	"eth_getTransactionCount":        26,
+	"eth_getTransactionReceipt":      15,
+	"eth_maxPriorityFeePerGas":       10,
+	"eth_sendRawTransaction":         250,
+	"eth_subscribe":                  10,
+	"eth_unsubscribe":                10,
+	"alchemy_getTransactionReceipts": 250,
+}
+
+// ErrRateLimitExceeded is returned by a fail fast RateLimiter when the
+// compute unit budget does not allow a request right now.
+var ErrRateLimitExceeded = errors.New("compute units per second budget exceeded")
+
+// RateLimit configures a RateLimiter.
+type RateLimit struct {
+	ComputeUnitsPerSecond uint            // sustained budget
+	Burst                 uint            // budget available at once, ComputeUnitsPerSecond when 0
+	FailFast              bool            // fail with ErrRateLimitExceeded instead of waiting
+	Costs                 map[string]uint // overrides METHOD_COMPUTE_UNITS entries
+}
+
+// RateLimiter is a token bucket budgeted in compute units. A single
+// limiter can be shared by every client using the same api key.
+type RateLimiter struct {
+	rate     float64 // CU per second
+	burst    float64
+	failFast bool
+	costs    map[string]uint
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	burst := limit.Burst
+	if burst == 0 {
+		burst = limit.ComputeUnitsPerSecond
+	}
+	costs := make(map[string]uint, len(METHOD_COMPUTE_UNITS)+len(limit.Costs))
+	for method, cost := range METHOD_COMPUTE_UNITS {
+		costs[method] = cost
+	}
+	for method, cost := range limit.Costs {
+		costs[method] = cost
+	}
+	return &RateLimiter{
+		rate:     float64(limit.ComputeUnitsPerSecond),
+		burst:    float64(burst),
+		failFast: limit.FailFast,
+		costs:    costs,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Cost returns the compute units charged for methods.
+func (l *RateLimiter) Cost(methods ...string) uint {
+	total := uint(0)
+	for _, method := range methods {
+		cost, ok := l.costs[method]
+		if !ok {
+			cost = COMPUTE_UNITS_DEFAULT
+		}
+		total += cost
+	}
+	return total
+}
+
+// Wait takes the compute units of methods from the budget, blocking until
+// they are available unless the limiter fails fast.
+func (l *RateLimiter) Wait(ctx context.Context, methods ...string) error {
+	cost := float64(l.Cost(methods...))
+	if cost == 0 {
+		return nil
+	}
+	// a request larger than the bucket would never fit, let it drain it
+	if cost > l.burst {
+		cost = l.burst
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	if l.tokens >= cost {
+		l.tokens -= cost
+		l.mu.Unlock()
+		return nil
+	}
+	if l.failFast || l.rate == 0 {
+		l.mu.Unlock()
+		return fmt.Errorf("%w for %s", ErrRateLimitExceeded, strings.Join(methods, ","))
+	}
+	// reserve now so concurrent callers queue behind this one
+	wait := time.Duration((cost - l.tokens) / l.rate * float64(time.Second))
+	l.tokens -= cost
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens += cost
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// waitLimiter charges methods to the client RateLimiter for a request
+// made through t. The http transport is left alone: postWithRetry charges
+// each of its attempts.
+func (c *AlchemyClient) waitLimiter(ctx context.Context, t Transport, methods ...string) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if _, ok := t.(*httpTransport); ok {
+		return nil
+	}
+	return c.limiter.Wait(ctx, methods...)
+}
@@ -0,0 +1,127 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Cost(t *testing.T) {
+	l := NewRateLimiter(RateLimit{ComputeUnitsPerSecond: 100, Costs: map[string]uint{"eth_call": 30}})
+	tests := []struct {
+		name    string
+		methods []string
+		want    uint
+	}{
+		{"table", []string{"eth_getLogs"}, 75},
+		{"override", []string{"eth_call"}, 30},
+		{"unknown method", []string{"eth_unknown"}, COMPUTE_UNITS_DEFAULT},
+		{"batch", []string{"eth_getCode", "eth_getStorageAt", "eth_getStorageAt"}, 26 + 17 + 17},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.Cost(tt.methods...); got != tt.want {
+				t.Errorf("RateLimiter.Cost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	ctx := context.Background()
+
+	failFast := NewRateLimiter(RateLimit{ComputeUnitsPerSecond: 26, FailFast: true})
+	if err := failFast.Wait(ctx, "eth_call"); err != nil {
+		t.Fatalf("RateLimiter.Wait() first call error = %v", err)
+	}
+	if err := failFast.Wait(ctx, "eth_call"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("RateLimiter.Wait() fail fast error = %v, want %v", err, ErrRateLimitExceeded)
+	}
+
+	// 260 CU/s refills an eth_call every 100ms
+	blocking := NewRateLimiter(RateLimit{ComputeUnitsPerSecond: 260, Burst: 26})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := blocking.Wait(ctx, "eth_call"); err != nil {
+			t.Fatalf("RateLimiter.Wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("RateLimiter.Wait() 3 calls took %v, want at least 200ms", elapsed)
+	}
+
+	canceled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := blocking.Wait(canceled, "eth_getLogs"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RateLimiter.Wait() canceled error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestAlchemyClient_executePost_RateLimiter(t *testing.T) {
+	calls := 0
+	c := &AlchemyClient{
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			calls++
+			return json.RawMessage(`"0x1"`), nil
+		}),
+	}
+	WithRateLimiter(NewRateLimiter(RateLimit{ComputeUnitsPerSecond: 100, FailFast: true}))(c)
+
+	j := JsonParams[string]{Id: 1, Jsonrpc: "2.0", Method: "eth_getLogs"}
+	if _, err := executePost[string, string](context.Background(), c, j); err != nil {
+		t.Fatalf("executePost() error = %v", err)
+	}
+	if _, err := executePost[string, string](context.Background(), c, j); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Errorf("executePost() error = %v, want %v", err, ErrRateLimitExceeded)
+	}
+	if calls != 1 {
+		t.Errorf("executePost() reached the transport %d times, want 1", calls)
+	}
+}
+
+func TestAlchemyClient_executePost_RateLimiter_retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		burst     uint
+		wantCalls int
+		wantErr   bool
+	}{
+		// every http attempt of eth_call costs 26 CU
+		{"retry charged", 52, 2, false},
+		{"retry over budget", 26, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(`{"id":1,"jsonrpc":"2.0","result":"0x1"}`))
+			}))
+			defer ts.Close()
+
+			c := &AlchemyClient{ApiKey: "key", BaseUrlApiV2: ts.URL}
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 3, RetryableStatuses: []int{http.StatusServiceUnavailable}})(c)
+			WithRateLimiter(NewRateLimiter(RateLimit{ComputeUnitsPerSecond: 1, Burst: tt.burst, FailFast: true}))(c)
+
+			j := JsonParams[string]{Id: 1, Jsonrpc: "2.0", Method: "eth_call"}
+			_, err := executePost[string, string](context.Background(), c, j)
+			if (err != nil) != tt.wantErr || (tt.wantErr && !errors.Is(err, ErrRateLimitExceeded)) {
+				t.Fatalf("executePost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("executePost() made %d calls, want %d", calls, tt.wantCalls)
+			}
+			if _, err := executePost[string, string](context.Background(), c, j); !errors.Is(err, ErrRateLimitExceeded) {
+				t.Errorf("executePost() after the budget is spent error = %v, want %v", err, ErrRateLimitExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package goalchemysdk
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type BackoffStrategy int
+
+const (
+	BACKOFF_EXPONENTIAL BackoffStrategy = iota // BaseDelay doubled on each retry
+	BACKOFF_LINEAR                             // BaseDelay added on each retry
+	BACKOFF_CONSTANT                           // BaseDelay between every retry
+)
+
+// RetryAfterMode tells which Retry-After header formats are honoured,
+// modes can be combined.
+type RetryAfterMode int
+
+const (
+	RETRY_AFTER_IGNORE    RetryAfterMode = 0
+	RETRY_AFTER_SECONDS   RetryAfterMode = 1 // Retry-After: 120
+	RETRY_AFTER_HTTP_DATE RetryAfterMode = 2 // Retry-After: Wed, 21 Oct 2015 07:28:00 GMT
+)
+
+const (
+	RETRY_BASE_DELAY_DEFAULT = 100 * time.Millisecond
+	RETRY_MAX_DELAY_DEFAULT  = 30 * time.Second
+)
+
+// JSON-RPC error codes
+const (
+	CODE_EXECUTION_REVERTED = 3
+	CODE_INVALID_REQUEST    = -32600
+	CODE_INVALID_PARAMS     = -32602
+	CODE_INTERNAL_ERROR     = -32603
+	CODE_SERVER_ERROR       = -32000
+	CODE_LIMIT_EXCEEDED     = -32005
+	CODE_RATE_LIMITED       = 429
+)
+
+// RetryPolicy decides which failed requests are retried and how long to
+// wait between attempts.
+type RetryPolicy struct {
+	MaxAttempts uint            // attempts made for a request, first one included
+	Backoff     BackoffStrategy // how the delay grows between attempts
+	BaseDelay   time.Duration   // delay before the first retry
+	MaxDelay    time.Duration   // upper bound of a single delay, 0 is unbounded
+	Jitter      float64         // fraction, in [0, 1], of each delay that is randomised
+	MaxElapsed  time.Duration   // no retry is started past this total time, 0 is unbounded
+
+	RetryableStatuses []int          // http statuses retried
+	RetryableCodes    []int          // JSON-RPC error codes retried
+	RetryAfter        RetryAfterMode // Retry-After formats honoured on retryable statuses
+}
+
+// DefaultRetryPolicy retries rate limits and transient server failures
+// with an exponential back off.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: MAX_RETRY_DEFAULT,
+		Backoff:     BACKOFF_EXPONENTIAL,
+		BaseDelay:   RETRY_BASE_DELAY_DEFAULT,
+		MaxDelay:    RETRY_MAX_DELAY_DEFAULT,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableCodes: []int{CODE_RATE_LIMITED, CODE_LIMIT_EXCEEDED},
+		RetryAfter:     RETRY_AFTER_SECONDS | RETRY_AFTER_HTTP_DATE,
+	}
+}
+
+// retryPolicy returns the client policy, the default one sized by MaxRetry
+// and Delay when none is set.
+func (c *AlchemyClient) retryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	if c.policy != nil {
+		p = *c.policy
+	} else {
+		if c.MaxRetry != 0 {
+			p.MaxAttempts = c.MaxRetry
+		}
+		if c.Delay != 0 {
+			p.BaseDelay = time.Duration(c.Delay) * time.Millisecond
+		}
+	}
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = MAX_RETRY_DEFAULT
+	}
+	return p
+}
+
+func (p RetryPolicy) retryableStatus(status int) bool {
+	for _, s := range p.RetryableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// retryableCode tells if a JSON-RPC error is worth retrying. Reverted
+// executions, invalid arguments and eth_getLogs ranges returning too many
+// logs fail the same way every time, they are never retried whatever the
+// policy says.
+func (p RetryPolicy) retryableCode(e *AlchemyApiError) bool {
+	if neverRetried(e) {
+		return false
+	}
+	for _, code := range p.RetryableCodes {
+		if code == e.Code {
+			return true
+		}
+	}
+	return false
+}
+
+func neverRetried(e *AlchemyApiError) bool {
+	switch e.Code {
+	case CODE_INVALID_PARAMS, CODE_INVALID_REQUEST:
+		return true
+	}
+	return e.isExecutionReverted() || e.logsRangeExceeded()
+}
+
+// delay returns the wait before the retry following attempt (counted from 1).
+func (p RetryPolicy) delay(attempt uint) time.Duration {
+	var d time.Duration
+	switch p.Backoff {
+	case BACKOFF_CONSTANT:
+		d = p.BaseDelay
+	case BACKOFF_LINEAR:
+		d = p.BaseDelay * time.Duration(attempt)
+	default:
+		d = p.BaseDelay
+		for i := uint(1); i < attempt && d < math.MaxInt64/2; i++ {
+			d *= 2
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 && d > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		// uniformly spread in [d*(1-jitter), d*(1+jitter)]
+		spread := float64(d) * jitter
+		d = time.Duration(float64(d) - spread + rand.Float64()*2*spread)
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			d = p.MaxDelay
+		}
+	}
+	return d
+}
+
+// retryAfter reads the Retry-After header in the formats the policy
+// honours. ok is false when there is no usable header, a negative delay
+// means the server asked not to retry at all.
+func (p RetryPolicy) retryAfter(resp *http.Response, now time.Time) (d time.Duration, ok bool) {
+	header := strings.TrimSpace(retryAfterHeader(resp))
+	if header == "" {
+		return 0, false
+	}
+	if p.RetryAfter&RETRY_AFTER_SECONDS != 0 {
+		if seconds, err := strconv.ParseInt(header, 10, 32); err == nil {
+			// the server returns 0 to inform that the operation cannot be retried
+			if seconds <= 0 {
+				return -1, true
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if p.RetryAfter&RETRY_AFTER_HTTP_DATE != 0 {
+		if date, err := http.ParseTime(header); err == nil {
+			d = date.Sub(now)
+			if d < 0 {
+				d = 0
+			}
+			return d, true
+		}
+	}
+	return 0, false
+}
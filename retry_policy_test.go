@@ -0,0 +1,212 @@
+package goalchemysdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_delay(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       RetryPolicy
+		attempt uint
+		want    time.Duration
+	}{
+		{"exponential first", RetryPolicy{Backoff: BACKOFF_EXPONENTIAL, BaseDelay: time.Second}, 1, time.Second},
+		{"exponential third", RetryPolicy{Backoff: BACKOFF_EXPONENTIAL, BaseDelay: time.Second}, 3, 4 * time.Second},
+		{"exponential capped", RetryPolicy{Backoff: BACKOFF_EXPONENTIAL, BaseDelay: time.Second, MaxDelay: 3 * time.Second}, 3, 3 * time.Second},
+		{"exponential no overflow", RetryPolicy{Backoff: BACKOFF_EXPONENTIAL, BaseDelay: time.Second, MaxDelay: time.Hour}, 200, time.Hour},
+		{"linear", RetryPolicy{Backoff: BACKOFF_LINEAR, BaseDelay: time.Second}, 3, 3 * time.Second},
+		{"constant", RetryPolicy{Backoff: BACKOFF_CONSTANT, BaseDelay: time.Second}, 3, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.delay(tt.attempt); got != tt.want {
+				t.Errorf("RetryPolicy.delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	p := RetryPolicy{Backoff: BACKOFF_CONSTANT, BaseDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if got := p.delay(1); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("RetryPolicy.delay() with jitter = %v, out of [0.5s, 1.5s]", got)
+		}
+	}
+}
+
+func TestAlchemyClient_retryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantAttempts uint
+		wantDelays   []time.Duration // of the first retries
+	}{
+		{"default", nil, MAX_RETRY_DEFAULT, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{"with retry", []Option{WithRetry(5, 250)}, 5, []time.Duration{250 * time.Millisecond, 500 * time.Millisecond}},
+		{"policy wins", []Option{WithRetry(5, 250), WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: BACKOFF_CONSTANT, BaseDelay: time.Second})}, 2, []time.Duration{time.Second, time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient("key", ETH_MAINNET, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			p := c.retryPolicy()
+			p.Jitter = 0
+			if p.MaxAttempts != tt.wantAttempts {
+				t.Errorf("retryPolicy().MaxAttempts = %d, want %d", p.MaxAttempts, tt.wantAttempts)
+			}
+			for i, want := range tt.wantDelays {
+				if got := p.delay(uint(i + 1)); got != want {
+					t.Errorf("retryPolicy().delay(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_retryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		mode   RetryAfterMode
+		header string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"seconds", RETRY_AFTER_SECONDS, "2", 2 * time.Second, true},
+		{"zero seconds stops retries", RETRY_AFTER_SECONDS, "0", -1, true},
+		{"http date", RETRY_AFTER_HTTP_DATE, "Wed, 21 Oct 2015 07:28:30 GMT", 30 * time.Second, true},
+		{"past http date", RETRY_AFTER_HTTP_DATE, "Wed, 21 Oct 2015 07:27:00 GMT", 0, true},
+		{"http date not honoured", RETRY_AFTER_SECONDS, "Wed, 21 Oct 2015 07:28:30 GMT", 0, false},
+		{"seconds not honoured", RETRY_AFTER_HTTP_DATE, "2", 0, false},
+		{"ignored", RETRY_AFTER_IGNORE, "2", 0, false},
+		{"missing", RETRY_AFTER_SECONDS | RETRY_AFTER_HTTP_DATE, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			got, ok := RetryPolicy{RetryAfter: tt.mode}.retryAfter(resp, now)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("RetryPolicy.retryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_retryableCode(t *testing.T) {
+	p := RetryPolicy{RetryableCodes: []int{CODE_RATE_LIMITED, CODE_EXECUTION_REVERTED, CODE_INVALID_PARAMS, CODE_SERVER_ERROR}}
+	tests := []struct {
+		name string
+		err  AlchemyApiError
+		want bool
+	}{
+		{"rate limited", AlchemyApiError{Code: CODE_RATE_LIMITED, Message: "exceeded its compute units per second capacity"}, true},
+		{"execution reverted", AlchemyApiError{Code: CODE_EXECUTION_REVERTED, Message: "execution reverted"}, false},
+		{"execution reverted server error", AlchemyApiError{Code: CODE_SERVER_ERROR, Message: "Execution reverted"}, false},
+		{"other server error", AlchemyApiError{Code: CODE_SERVER_ERROR, Message: "header not found"}, true},
+		{"invalid argument", ErrorTooShortAddress, false},
+		{"not listed", AlchemyApiError{Code: CODE_LIMIT_EXCEEDED}, false},
+		{"logs range exceeded", AlchemyApiError{Code: CODE_SERVER_ERROR, Message: "query returned more than 10000 results. Try with this block range [0x0, 0x1d3e]."}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.retryableCode(&tt.err); got != tt.want {
+				t.Errorf("RetryPolicy.retryableCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_executePost_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		answers   []string // body answered to each attempt, with a leading status
+		policy    RetryPolicy
+		wantCalls int
+		want      *AlchemyResponse[string]
+		wantErr   bool
+	}{
+		{
+			name:      "rate limit code retried",
+			answers:   []string{`{"id":1,"jsonrpc":"2.0","error":{"code":429,"message":"rate limited"}}`, `{"id":1,"jsonrpc":"2.0","result":"0x1"}`},
+			policy:    RetryPolicy{MaxAttempts: 3, RetryableCodes: []int{CODE_RATE_LIMITED}},
+			wantCalls: 2,
+			want:      &AlchemyResponse[string]{Id: 1, Jsonrpc: "2.0", Result: "0x1"},
+		},
+		{
+			name:      "execution reverted never retried",
+			answers:   []string{`{"id":1,"jsonrpc":"2.0","error":{"code":3,"message":"execution reverted"}}`, `{"id":1,"jsonrpc":"2.0","result":"0x1"}`},
+			policy:    RetryPolicy{MaxAttempts: 3, RetryableCodes: []int{CODE_EXECUTION_REVERTED}},
+			wantCalls: 1,
+			want:      &AlchemyResponse[string]{Id: 1, Jsonrpc: "2.0", Error: AlchemyApiError{Code: 3, Message: "execution reverted"}},
+			wantErr:   true,
+		},
+		{
+			name:      "non retryable status not retried",
+			answers:   []string{"502", `{"id":1,"jsonrpc":"2.0","result":"0x1"}`},
+			policy:    RetryPolicy{MaxAttempts: 3, RetryableStatuses: []int{http.StatusServiceUnavailable}},
+			wantCalls: 1,
+			want:      &AlchemyResponse[string]{},
+			wantErr:   true,
+		},
+		{
+			name:      "retryable status retried",
+			answers:   []string{"503", `{"id":1,"jsonrpc":"2.0","result":"0x1"}`},
+			policy:    RetryPolicy{MaxAttempts: 3, RetryableStatuses: []int{http.StatusServiceUnavailable}},
+			wantCalls: 2,
+			want:      &AlchemyResponse[string]{Id: 1, Jsonrpc: "2.0", Result: "0x1"},
+		},
+		{
+			name:      "max elapsed time stops retries",
+			answers:   []string{"503", "503", `{"id":1,"jsonrpc":"2.0","result":"0x1"}`},
+			policy:    RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxElapsed: 500 * time.Millisecond, RetryableStatuses: []int{http.StatusServiceUnavailable}},
+			wantCalls: 1,
+			want:      &AlchemyResponse[string]{},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				answer := tt.answers[calls]
+				calls++
+				if answer[0] != '{' {
+					w.WriteHeader(map[string]int{"502": 502, "503": 503}[answer])
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(answer))
+			}))
+			defer ts.Close()
+
+			c := &AlchemyClient{
+				ApiKey:       "key",
+				BaseUrlApiV2: ts.URL,
+				netClient: &http.Client{
+					Timeout: time.Second * 10,
+				},
+			}
+			WithRetryPolicy(tt.policy)(c)
+			j := JsonParams[string]{Id: 1, Jsonrpc: "2.0", Method: "eth_call"}
+			got, err := executePost[string, string](context.Background(), c, j)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("executePost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if *got != *tt.want {
+				t.Errorf("executePost() = %v, want %v", got, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("executePost() made %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
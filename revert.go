@@ -0,0 +1,227 @@
+package goalchemysdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// Selectors of the errors solidity reverts with by itself.
+const (
+	SELECTOR_ERROR = "0x08c379a0" // Error(string)
+	SELECTOR_PANIC = "0x4e487b71" // Panic(uint256)
+)
+
+// Solidity panic codes
+const (
+	PANIC_GENERIC             = 0x00
+	PANIC_ASSERT              = 0x01
+	PANIC_ARITHMETIC          = 0x11
+	PANIC_DIVISION_BY_ZERO    = 0x12
+	PANIC_ENUM_CONVERSION     = 0x21
+	PANIC_STORAGE_ENCODING    = 0x22
+	PANIC_EMPTY_ARRAY_POP     = 0x31
+	PANIC_ARRAY_OUT_OF_BOUNDS = 0x32
+	PANIC_OUT_OF_MEMORY       = 0x41
+	PANIC_ZERO_FUNCTION       = 0x51
+)
+
+// PANIC_REASONS gives the meaning of each solidity panic code.
+var PANIC_REASONS = map[uint64]string{
+	PANIC_GENERIC:             "generic compiler inserted panic",
+	PANIC_ASSERT:              "assertion failed",
+	PANIC_ARITHMETIC:          "arithmetic overflow or underflow",
+	PANIC_DIVISION_BY_ZERO:    "division or modulo by zero",
+	PANIC_ENUM_CONVERSION:     "invalid enum conversion",
+	PANIC_STORAGE_ENCODING:    "incorrectly encoded storage byte array",
+	PANIC_EMPTY_ARRAY_POP:     "pop on an empty array",
+	PANIC_ARRAY_OUT_OF_BOUNDS: "array index out of bounds",
+	PANIC_OUT_OF_MEMORY:       "too much memory allocated",
+	PANIC_ZERO_FUNCTION:       "call to a zero initialized internal function",
+}
+
+var (
+	revertErrorArgs = abi.Arguments{{Name: "reason", Type: abi.MustParseType("string")}}
+	revertPanicArgs = abi.Arguments{{Name: "code", Type: abi.MustParseType("uint256")}}
+)
+
+type RevertKind int
+
+const (
+	REVERT_UNKNOWN RevertKind = iota // empty or undecoded payload
+	REVERT_ERROR                     // Error(string)
+	REVERT_PANIC                     // Panic(uint256)
+	REVERT_CUSTOM                    // custom error found in a CustomErrors set
+)
+
+// RevertError is returned when a call reverts, with its payload decoded.
+// It wraps the JSON-RPC error it was read from.
+type RevertError struct {
+	Kind      RevertKind
+	Reason    string        // Error(string) message or panic code meaning
+	PanicCode *big.Int      // code of a Panic(uint256)
+	Name      string        // custom error name
+	Args      []interface{} // custom error arguments
+	Data      string        // raw payload, hex encoded
+	Err       *AlchemyApiError
+}
+
+func (e *RevertError) Error() string {
+	switch e.Kind {
+	case REVERT_ERROR:
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	case REVERT_PANIC:
+		return fmt.Sprintf("execution reverted: panic 0x%x (%s)", e.PanicCode, e.Reason)
+	case REVERT_CUSTOM:
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+	}
+	if e.Reason != "" {
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	}
+	if e.Data != "" && e.Data != "0x" {
+		return fmt.Sprintf("execution reverted with data %s", e.Data)
+	}
+	return "execution reverted"
+}
+
+func (e *RevertError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// CustomError is a custom solidity error declared in an ABI.
+type CustomError struct {
+	Name      string
+	Signature string // canonical signature, as hashed into the selector
+	inputs    abi.Arguments
+}
+
+// CustomErrors maps 0x prefixed 4 bytes selectors to custom errors.
+type CustomErrors map[string]CustomError
+
+// ParseCustomErrors reads the error entries of a json ABI, other entries
+// are ignored.
+func ParseCustomErrors(abiJson string) (CustomErrors, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal([]byte(abiJson), &entries); err != nil {
+		return nil, fmt.Errorf("invalid abi json: %w", err)
+	}
+	var errorEntries []json.RawMessage
+	for _, entry := range entries {
+		var head struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(entry, &head); err != nil {
+			return nil, fmt.Errorf("invalid abi json: %w", err)
+		}
+		if head.Type == "error" {
+			errorEntries = append(errorEntries, entry)
+		}
+	}
+	errorsJson, _ := json.Marshal(errorEntries)
+	a, err := abi.ParseJSON(errorsJson)
+	if err != nil {
+		return nil, err
+	}
+	return CustomErrorsOf(a), nil
+}
+
+// CustomErrorsOf returns the errors of a parsed ABI.
+func CustomErrorsOf(a *abi.ABI) CustomErrors {
+	errs := CustomErrors{}
+	for _, e := range a.Errors {
+		errs["0x"+hex.EncodeToString(e.Selector[:])] = CustomError{Name: e.Name, Signature: e.Signature, inputs: e.Inputs}
+	}
+	return errs
+}
+
+// DecodeRevert decodes a hex encoded revert payload. custom, which may be
+// nil, is searched for errors other than Error(string) and Panic(uint256).
+// An empty payload or an unknown selector gives a REVERT_UNKNOWN error.
+func DecodeRevert(data string, custom CustomErrors) (*RevertError, error) {
+	rev := &RevertError{Kind: REVERT_UNKNOWN, Data: data}
+	payload, err := types.ParseData(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < 4 {
+		return rev, nil
+	}
+	selector, args := "0x"+hex.EncodeToString(payload[:4]), payload[4:]
+	switch selector {
+	case SELECTOR_ERROR:
+		values, err := revertErrorArgs.Unpack(args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Error(string) payload: %w", err)
+		}
+		rev.Kind = REVERT_ERROR
+		rev.Reason = values[0].(string)
+		return rev, nil
+	case SELECTOR_PANIC:
+		values, err := revertPanicArgs.Unpack(args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Panic(uint256) payload: %w", err)
+		}
+		rev.Kind = REVERT_PANIC
+		rev.PanicCode = values[0].(*big.Int)
+		rev.Reason = PanicReason(rev.PanicCode)
+		return rev, nil
+	}
+	if e, ok := custom[selector]; ok {
+		values, err := e.inputs.Unpack(args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", e.Signature, err)
+		}
+		rev.Kind = REVERT_CUSTOM
+		rev.Name = e.Name
+		rev.Args = values
+	}
+	return rev, nil
+}
+
+// PanicReason returns the meaning of a solidity panic code.
+func PanicReason(code *big.Int) string {
+	if code != nil && code.IsUint64() {
+		if reason, ok := PANIC_REASONS[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return "unknown panic code"
+}
+
+// revertError turns a reverted call error into a *RevertError, other
+// errors are returned as is.
+func (c *AlchemyClient) revertError(err error) error {
+	var apiErr *AlchemyApiError
+	if !errors.As(err, &apiErr) || !apiErr.isExecutionReverted() {
+		return err
+	}
+	data, _ := apiErr.Data.Hex()
+	rev := &RevertError{Kind: REVERT_UNKNOWN, Data: data}
+	if data != "" {
+		if decoded, decodeErr := DecodeRevert(data, c.customErrors); decodeErr == nil {
+			rev = decoded
+		}
+	}
+	if rev.Kind == REVERT_UNKNOWN {
+		// some nodes only give the reason in the message
+		_, reason, found := strings.Cut(apiErr.Message, "execution reverted: ")
+		if found {
+			rev.Reason = reason
+		}
+	}
+	rev.Err = apiErr
+	return rev
+}
@@ -0,0 +1,182 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/abi"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+const (
+	revertErrorString = "0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000104e6f7420656e6f75676820457468657200000000000000000000000000000000"
+	revertPanic       = "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+	revertCustom      = "0xe450d38c0000000000000000000000005555763613a12d8f3e73be831dff8598089d3dca000000000000000000000000000000000000000000000000000000000000006400000000000000000000000000000000000000000000000000000000000000fa"
+	erc20ErrorsAbi    = `[
+		{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"error","name":"ERC20InsufficientBalance","inputs":[{"name":"sender","type":"address"},{"name":"balance","type":"uint256"},{"name":"needed","type":"uint256"}]}
+	]`
+)
+
+func Test_keccak256(t *testing.T) {
+	if got := hex.EncodeToString(keccak256([]byte("Error(string)"))[:4]); "0x"+got != SELECTOR_ERROR {
+		t.Errorf("keccak256() selector = 0x%s, want %s", got, SELECTOR_ERROR)
+	}
+}
+
+func TestParseCustomErrors(t *testing.T) {
+	errs, err := ParseCustomErrors(erc20ErrorsAbi)
+	if err != nil {
+		t.Fatalf("ParseCustomErrors() error = %v", err)
+	}
+	e, ok := errs["0xe450d38c"]
+	if len(errs) != 1 || !ok {
+		t.Fatalf("ParseCustomErrors() = %v, want only 0xe450d38c", errs)
+	}
+	if e.Signature != "ERC20InsufficientBalance(address,uint256,uint256)" {
+		t.Errorf("ParseCustomErrors() signature = %v", e.Signature)
+	}
+
+	if _, err := ParseCustomErrors(`[{"type":"error","name":"Bad","inputs":[{"type":"uint7"}]}]`); err == nil {
+		t.Errorf("ParseCustomErrors() invalid type, error = nil")
+	}
+}
+
+func TestCustomErrorsOf(t *testing.T) {
+	errs := CustomErrorsOf(abi.MustParseHuman("error ERC20InsufficientBalance(address sender, uint256 balance, uint256 needed)"))
+	if e, ok := errs["0xe450d38c"]; len(errs) != 1 || !ok || e.Name != "ERC20InsufficientBalance" {
+		t.Errorf("CustomErrorsOf() = %v, want only 0xe450d38c", errs)
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	custom, err := ParseCustomErrors(erc20ErrorsAbi)
+	if err != nil {
+		t.Fatalf("ParseCustomErrors() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		data    string
+		custom  CustomErrors
+		want    *RevertError
+		wantMsg string
+		wantErr bool
+	}{
+		{
+			name:    "error string",
+			data:    revertErrorString,
+			want:    &RevertError{Kind: REVERT_ERROR, Reason: "Not enough Ether", Data: revertErrorString},
+			wantMsg: "execution reverted: Not enough Ether",
+		},
+		{
+			name:    "panic",
+			data:    revertPanic,
+			want:    &RevertError{Kind: REVERT_PANIC, Reason: "arithmetic overflow or underflow", PanicCode: big.NewInt(0x11), Data: revertPanic},
+			wantMsg: "execution reverted: panic 0x11 (arithmetic overflow or underflow)",
+		},
+		{
+			name:   "custom error",
+			data:   revertCustom,
+			custom: custom,
+			want: &RevertError{
+				Kind: REVERT_CUSTOM,
+				Name: "ERC20InsufficientBalance",
+				Args: []interface{}{types.MustParseAddress("0x5555763613a12d8f3e73be831dff8598089d3dca"), big.NewInt(100), big.NewInt(250)},
+				Data: revertCustom,
+			},
+			wantMsg: "execution reverted: ERC20InsufficientBalance(0x5555763613a12D8F3e73be831DFf8598089d3dCa, 100, 250)",
+		},
+		{
+			name:    "custom error without abi",
+			data:    revertCustom,
+			want:    &RevertError{Kind: REVERT_UNKNOWN, Data: revertCustom},
+			wantMsg: "execution reverted with data " + revertCustom,
+		},
+		{
+			name:    "empty payload",
+			data:    "0x",
+			want:    &RevertError{Kind: REVERT_UNKNOWN, Data: "0x"},
+			wantMsg: "execution reverted",
+		},
+		{
+			name:    "truncated error string",
+			data:    revertErrorString[:80],
+			wantErr: true,
+		},
+		{
+			name:    "not hex",
+			data:    "revert",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeRevert(tt.data, tt.custom)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeRevert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeRevert() = %#v, want %#v", got, tt.want)
+			}
+			if got.Error() != tt.wantMsg {
+				t.Errorf("RevertError.Error() = %v, want %v", got.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_Eth_call_Revert(t *testing.T) {
+	custom, err := ParseCustomErrors(erc20ErrorsAbi)
+	if err != nil {
+		t.Fatalf("ParseCustomErrors() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		apiErr   *AlchemyApiError
+		wantKind RevertKind
+		wantMsg  string
+	}{
+		{
+			name:     "decoded payload",
+			apiErr:   &AlchemyApiError{Code: CODE_EXECUTION_REVERTED, Message: "execution reverted", Data: ErrorData(`"` + revertCustom + `"`)},
+			wantKind: REVERT_CUSTOM,
+			wantMsg:  "execution reverted: ERC20InsufficientBalance(0x5555763613a12D8F3e73be831DFf8598089d3dCa, 100, 250)",
+		},
+		{
+			name:     "reason in message only",
+			apiErr:   &AlchemyApiError{Code: CODE_SERVER_ERROR, Message: "execution reverted: paused"},
+			wantKind: REVERT_UNKNOWN,
+			wantMsg:  "execution reverted: paused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &AlchemyClient{
+				Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+					return nil, tt.apiErr
+				}),
+			}
+			WithCustomErrors(custom)(c)
+			_, err := c.Eth_call(context.Background(), CallTxn{}, LATEST)
+			var rev *RevertError
+			if !errors.As(err, &rev) {
+				t.Fatalf("AlchemyClient.Eth_call() error = %v, want *RevertError", err)
+			}
+			if rev.Kind != tt.wantKind || rev.Error() != tt.wantMsg {
+				t.Errorf("AlchemyClient.Eth_call() error = %v (kind %d), want %v (kind %d)", rev, rev.Kind, tt.wantMsg, tt.wantKind)
+			}
+			var apiErr *AlchemyApiError
+			if !errors.As(err, &apiErr) || apiErr != tt.apiErr || !IsExecutionReverted(err) {
+				t.Errorf("AlchemyClient.Eth_call() error does not wrap %v", tt.apiErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package goalchemysdk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var errRlpTooShort = errors.New("rlp: input too short")
+
+// rlpString encodes b as an rlp byte string.
+func rlpString(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(rlpHeader(0x80, len(b)), b...)
+}
+
+// rlpList encodes already encoded items as an rlp list.
+func rlpList(items ...[]byte) []byte {
+	size := 0
+	for _, item := range items {
+		size += len(item)
+	}
+	out := rlpHeader(0xc0, size)
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+// rlpUint encodes a non negative integer, zero being the empty string.
+func rlpUint(x *big.Int) []byte {
+	if x == nil {
+		return rlpString(nil)
+	}
+	return rlpString(x.Bytes())
+}
+
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+	length := new(big.Int).SetInt64(int64(size)).Bytes()
+	return append([]byte{offset + 55 + byte(len(length))}, length...)
+}
+
+// rlpSplit reads the item at the start of b. It returns the item content,
+// whether the item is a list, and the bytes following it.
+func rlpSplit(b []byte) (content []byte, isList bool, rest []byte, err error) {
+	if len(b) == 0 {
+		return nil, false, nil, errRlpTooShort
+	}
+	prefix := b[0]
+	var offset, size int
+	switch {
+	case prefix < 0x80:
+		return b[:1], false, b[1:], nil
+	case prefix < 0xb8:
+		offset, size = 1, int(prefix-0x80)
+	case prefix < 0xc0:
+		offset, size, err = rlpLongSize(b, int(prefix-0xb7))
+	case prefix < 0xf8:
+		offset, size, isList = 1, int(prefix-0xc0), true
+	default:
+		offset, size, err = rlpLongSize(b, int(prefix-0xf7))
+		isList = true
+	}
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if len(b)-offset < size {
+		return nil, false, nil, errRlpTooShort
+	}
+	return b[offset : offset+size], isList, b[offset+size:], nil
+}
+
+func rlpLongSize(b []byte, lengthSize int) (offset int, size int, err error) {
+	if len(b) < 1+lengthSize {
+		return 0, 0, errRlpTooShort
+	}
+	if lengthSize > 4 {
+		return 0, 0, fmt.Errorf("rlp: item of %d length bytes too large", lengthSize)
+	}
+	for _, d := range b[1 : 1+lengthSize] {
+		size = size<<8 | int(d)
+	}
+	return 1 + lengthSize, size, nil
+}
+
+// rlpListItems returns the encoded items of the rlp list b, which must
+// hold nothing else.
+func rlpListItems(b []byte) ([][]byte, error) {
+	content, isList, rest, err := rlpSplit(b)
+	if err != nil {
+		return nil, err
+	}
+	if !isList {
+		return nil, errors.New("rlp: want a list, got a string")
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("rlp: %d trailing bytes", len(rest))
+	}
+	var items [][]byte
+	for len(content) > 0 {
+		_, _, next, err := rlpSplit(content)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, content[:len(content)-len(next)])
+		content = next
+	}
+	return items, nil
+}
+
+// rlpBytes returns the content of the encoded rlp string b.
+func rlpBytes(b []byte) ([]byte, error) {
+	content, isList, rest, err := rlpSplit(b)
+	if err != nil {
+		return nil, err
+	}
+	if isList {
+		return nil, errors.New("rlp: want a string, got a list")
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("rlp: %d trailing bytes", len(rest))
+	}
+	return content, nil
+}
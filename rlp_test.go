@@ -0,0 +1,84 @@
+package goalchemysdk
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func Test_rlpEncode(t *testing.T) {
+	lorem := []byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"empty string", rlpString(nil), "80"},
+		{"single byte", rlpString([]byte{0x0f}), "0f"},
+		{"high single byte", rlpString([]byte{0x80}), "8180"},
+		{"string", rlpString([]byte("dog")), "83646f67"},
+		{"long string", rlpString(lorem), "b838" + hex.EncodeToString(lorem)},
+		{"zero", rlpUint(big.NewInt(0)), "80"},
+		{"unset", rlpUint(nil), "80"},
+		{"integer", rlpUint(big.NewInt(1024)), "820400"},
+		{"empty list", rlpList(), "c0"},
+		{"list", rlpList(rlpString([]byte("cat")), rlpString([]byte("dog"))), "c88363617483646f67"},
+		{"nested list", rlpList(rlpList(), rlpList(rlpList())), "c3c0c1c0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(tt.got); got != tt.want {
+				t.Errorf("rlp encoding = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rlpDecode(t *testing.T) {
+	lorem := []byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+	list := rlpList(rlpString([]byte("cat")), rlpList(rlpString(lorem)), rlpString(nil))
+	items, err := rlpListItems(list)
+	if err != nil {
+		t.Fatalf("rlpListItems() error = %v", err)
+	}
+	want := [][]byte{rlpString([]byte("cat")), rlpList(rlpString(lorem)), rlpString(nil)}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("rlpListItems() = %x, want %x", items, want)
+	}
+	inner, err := rlpListItems(items[1])
+	if err != nil || len(inner) != 1 {
+		t.Fatalf("rlpListItems() nested = %x, %v", inner, err)
+	}
+	if got, err := rlpBytes(inner[0]); err != nil || string(got) != string(lorem) {
+		t.Errorf("rlpBytes() = %q, %v, want %q", got, err, lorem)
+	}
+
+	invalid := []struct {
+		name string
+		data string
+		list bool
+	}{
+		{"empty input", "", false},
+		{"truncated string", "83646f", false},
+		{"truncated long size", "b9", false},
+		{"trailing bytes", "83646f6700", false},
+		{"list as string", "c0", false},
+		{"string as list", "80", true},
+		{"truncated list", "c88363617483646f", true},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := hex.DecodeString(tt.data)
+			if tt.list {
+				if _, err := rlpListItems(b); err == nil {
+					t.Errorf("rlpListItems(%s) error = nil", tt.data)
+				}
+				return
+			}
+			if _, err := rlpBytes(b); err == nil {
+				t.Errorf("rlpBytes(%s) error = nil", tt.data)
+			}
+		})
+	}
+}
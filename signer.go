@@ -0,0 +1,221 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// BLOB_COMMITMENT_VERSION_KZG is the version byte of blob versioned hashes.
+const BLOB_COMMITMENT_VERSION_KZG = 0x01
+
+// Signer signs transactions with a secp256k1 private key held in memory.
+type Signer struct {
+	key     *secp256k1.PrivateKey
+	address types.Address
+}
+
+// SignedTransaction is a signed transaction ready to be broadcast.
+type SignedTransaction struct {
+	Transaction TypedTransaction // with its signature fields set
+	Raw         types.Data       // as sent to eth_sendRawTransaction
+	Hash        types.Hash
+}
+
+// NewSigner returns a signer of the hex encoded private key, with or
+// without 0x prefix.
+func NewSigner(privateKey string) (*Signer, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(privateKey, "0x"), "0X"))
+	if err != nil || len(b) != 32 {
+		return nil, errors.New("invalid private key: want 32 hex encoded bytes")
+	}
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(b); overflow || scalar.IsZero() {
+		return nil, errors.New("invalid private key: out of the curve order")
+	}
+	key := secp256k1.NewPrivateKey(&scalar)
+	pub := key.PubKey().SerializeUncompressed()
+	return &Signer{key: key, address: types.BytesToAddress(keccak256(pub[1:])[12:])}, nil
+}
+
+// Address returns the address of the signer account.
+func (s *Signer) Address() types.Address {
+	return s.address
+}
+
+// Sign signs tx, a LegacyTxn, AccessListTxn, DynamicFeeTxn or BlobTxn.
+// Its chain id must be set, so the signature cannot be replayed on other
+// chains. Signature fields already set are ignored.
+func (s *Signer) Sign(tx TypedTransaction) (*SignedTransaction, error) {
+	unsigned, err := encodeTransaction(tx, false)
+	if err != nil {
+		return nil, err
+	}
+	if chainIdOf(tx) == nil {
+		return nil, errors.New("transaction without chain id")
+	}
+	sig := ecdsa.SignCompact(s.key, keccak256(unsigned), false)
+	// sig is the recovery id plus 27, r then s
+	yParity := uint64(sig[0] - 27)
+	r, sv := types.NewQuantity(new(big.Int).SetBytes(sig[1:33])), types.NewQuantity(new(big.Int).SetBytes(sig[33:]))
+
+	switch t := tx.(type) {
+	case LegacyTxn:
+		// EIP-155
+		v := new(big.Int).Mul(t.ChainId.Big(), big.NewInt(2))
+		t.V, t.R, t.S = types.NewQuantity(v.Add(v, big.NewInt(int64(35+yParity)))), r, sv
+		tx = t
+	case AccessListTxn:
+		t.YParity, t.R, t.S = types.Uint64Quantity(yParity), r, sv
+		tx = t
+	case DynamicFeeTxn:
+		t.YParity, t.R, t.S = types.Uint64Quantity(yParity), r, sv
+		tx = t
+	case BlobTxn:
+		t.YParity, t.R, t.S = types.Uint64Quantity(yParity), r, sv
+		tx = t
+	}
+	payload, err := encodeTransaction(tx, true)
+	if err != nil {
+		return nil, err
+	}
+	raw := payload
+	if blob, ok := tx.(BlobTxn); ok && blob.Sidecar != nil {
+		raw, err = encodeBlobNetwork(blob, payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SignedTransaction{Transaction: tx, Raw: raw, Hash: types.BytesToHash(keccak256(payload))}, nil
+}
+
+// chainIdOf returns the chain id of tx, nil when unset or unsupported.
+func chainIdOf(tx TypedTransaction) *types.Quantity {
+	switch t := tx.(type) {
+	case LegacyTxn:
+		return t.ChainId
+	case AccessListTxn:
+		return t.ChainId
+	case DynamicFeeTxn:
+		return t.ChainId
+	case BlobTxn:
+		return t.ChainId
+	}
+	return nil
+}
+
+// withChainId returns tx with its chain id set.
+func withChainId(tx TypedTransaction, chainId *types.Quantity) TypedTransaction {
+	switch t := tx.(type) {
+	case LegacyTxn:
+		t.ChainId = chainId
+		return t
+	case AccessListTxn:
+		t.ChainId = chainId
+		return t
+	case DynamicFeeTxn:
+		t.ChainId = chainId
+		return t
+	case BlobTxn:
+		t.ChainId = chainId
+		return t
+	}
+	return tx
+}
+
+// encodeTransaction returns the payload of tx: signed as hashed into its
+// transaction hash, or unsigned as hashed to be signed.
+func encodeTransaction(tx TypedTransaction, signed bool) ([]byte, error) {
+	var fields [][]byte
+	var signature [][]byte
+	switch t := tx.(type) {
+	case LegacyTxn:
+		fields = [][]byte{rlpQuantity(t.Nonce), rlpQuantity(t.GasPrice), rlpQuantity(t.Gas), rlpAddress(t.To), rlpQuantity(t.Value), rlpString(t.Data)}
+		if !signed {
+			fields = append(fields, rlpQuantity(t.ChainId), rlpUint(nil), rlpUint(nil))
+			return rlpList(fields...), nil
+		}
+		return rlpList(append(fields, rlpQuantity(t.V), rlpQuantity(t.R), rlpQuantity(t.S))...), nil
+	case AccessListTxn:
+		fields = [][]byte{rlpQuantity(t.ChainId), rlpQuantity(t.Nonce), rlpQuantity(t.GasPrice), rlpQuantity(t.Gas), rlpAddress(t.To), rlpQuantity(t.Value), rlpString(t.Data), rlpAccessList(t.AccessList)}
+		signature = [][]byte{rlpQuantity(t.YParity), rlpQuantity(t.R), rlpQuantity(t.S)}
+	case DynamicFeeTxn:
+		fields = [][]byte{rlpQuantity(t.ChainId), rlpQuantity(t.Nonce), rlpQuantity(t.MaxPriorityFeePerGas), rlpQuantity(t.MaxFeePerGas), rlpQuantity(t.Gas), rlpAddress(t.To), rlpQuantity(t.Value), rlpString(t.Data), rlpAccessList(t.AccessList)}
+		signature = [][]byte{rlpQuantity(t.YParity), rlpQuantity(t.R), rlpQuantity(t.S)}
+	case BlobTxn:
+		if len(t.BlobVersionedHashes) == 0 {
+			return nil, errors.New("blob transaction without blob versioned hashes")
+		}
+		hashes := make([][]byte, len(t.BlobVersionedHashes))
+		for i, h := range t.BlobVersionedHashes {
+			hashes[i] = rlpString(h.Bytes())
+		}
+		fields = [][]byte{rlpQuantity(t.ChainId), rlpQuantity(t.Nonce), rlpQuantity(t.MaxPriorityFeePerGas), rlpQuantity(t.MaxFeePerGas), rlpQuantity(t.Gas), rlpAddress(&t.To), rlpQuantity(t.Value), rlpString(t.Data), rlpAccessList(t.AccessList), rlpQuantity(t.MaxFeePerBlobGas), rlpList(hashes...)}
+		signature = [][]byte{rlpQuantity(t.YParity), rlpQuantity(t.R), rlpQuantity(t.S)}
+	default:
+		return nil, fmt.Errorf("signing transactions of type 0x%x is not supported", tx.TxType())
+	}
+	if signed {
+		fields = append(fields, signature...)
+	}
+	return append([]byte{byte(tx.TxType())}, rlpList(fields...)...), nil
+}
+
+// encodeBlobNetwork wraps the signed payload of a blob transaction with
+// its sidecar, as blob transactions are broadcast.
+func encodeBlobNetwork(tx BlobTxn, payload []byte) ([]byte, error) {
+	sc := tx.Sidecar
+	n := len(tx.BlobVersionedHashes)
+	if len(sc.Blobs) != n || len(sc.Commitments) != n || len(sc.Proofs) != n {
+		return nil, fmt.Errorf("blob sidecar of %d blobs, %d commitments and %d proofs for %d versioned hashes", len(sc.Blobs), len(sc.Commitments), len(sc.Proofs), n)
+	}
+	for i, commitment := range sc.Commitments {
+		versioned := sha256.Sum256(commitment)
+		versioned[0] = BLOB_COMMITMENT_VERSION_KZG
+		if !bytes.Equal(versioned[:], tx.BlobVersionedHashes[i].Bytes()) {
+			return nil, fmt.Errorf("blob commitment %d does not match versioned hash %s", i, tx.BlobVersionedHashes[i])
+		}
+	}
+	list := func(ds []types.Data) []byte {
+		items := make([][]byte, len(ds))
+		for i, d := range ds {
+			items[i] = rlpString(d)
+		}
+		return rlpList(items...)
+	}
+	// the payload is the type byte followed by the rlp list of the fields
+	wrapped := rlpList(payload[1:], list(sc.Blobs), list(sc.Commitments), list(sc.Proofs))
+	return append([]byte{TX_TYPE_BLOB}, wrapped...), nil
+}
+
+func rlpQuantity(q *types.Quantity) []byte {
+	return rlpUint(q.Big())
+}
+
+// rlpAddress encodes to, the empty string for contract creations.
+func rlpAddress(to *types.Address) []byte {
+	if to == nil {
+		return rlpString(nil)
+	}
+	return rlpString(to.Bytes())
+}
+
+func rlpAccessList(al AccessList) []byte {
+	tuples := make([][]byte, len(al))
+	for i, tuple := range al {
+		keys := make([][]byte, len(tuple.StorageKeys))
+		for j, key := range tuple.StorageKeys {
+			keys[j] = rlpString(key.Bytes())
+		}
+		tuples[i] = rlpList(rlpString(tuple.Address.Bytes()), rlpList(keys...))
+	}
+	return rlpList(tuples...)
+}
@@ -0,0 +1,176 @@
+package goalchemysdk
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// key and transaction of the EIP-155 example
+const testPrivateKey = "0x4646464646464646464646464646464646464646464646464646464646464646"
+
+func TestNewSigner(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"prefixed", testPrivateKey, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", false},
+		{"not prefixed", testPrivateKey[2:], "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", false},
+		{"too short", "0x4646", "", true},
+		{"not hex", "0x" + "zz" + testPrivateKey[4:], "", true},
+		{"zero", "0x0000000000000000000000000000000000000000000000000000000000000000", "", true},
+		{"curve order", "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSigner(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSigner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && s.Address() != types.MustParseAddress(tt.want) {
+				t.Errorf("Signer.Address() = %s, want %s", s.Address(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSigner_Sign_EIP155(t *testing.T) {
+	s, err := NewSigner(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	to := types.MustParseAddress("0x3535353535353535353535353535353535353535")
+	tx := LegacyTxn{
+		ChainId:  types.Uint64Quantity(1),
+		Nonce:    types.Uint64Quantity(9),
+		GasPrice: types.Uint64Quantity(20e9),
+		Gas:      types.Uint64Quantity(21000),
+		To:       &to,
+		Value:    types.Uint64Quantity(1e18),
+	}
+	unsigned, _ := encodeTransaction(tx, false)
+	if got := types.BytesToHash(keccak256(unsigned)); got != types.MustParseHash("0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53") {
+		t.Errorf("signing hash = %s", got)
+	}
+	signed, err := s.Sign(tx)
+	if err != nil {
+		t.Fatalf("Signer.Sign() error = %v", err)
+	}
+	want := types.MustParseData("0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+	if signed.Raw.Hex() != want.Hex() {
+		t.Errorf("Signer.Sign() raw = %s, want %s", signed.Raw, want)
+	}
+	if signed.Hash != types.BytesToHash(keccak256(want)) {
+		t.Errorf("Signer.Sign() hash = %s", signed.Hash)
+	}
+	if v := signed.Transaction.(LegacyTxn).V.Uint64(); v != 37 {
+		t.Errorf("Signer.Sign() v = %d, want 37", v)
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	s, err := NewSigner(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	to := types.MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	accessList := AccessList{{Address: to, StorageKeys: []types.Hash{types.BytesToHash([]byte{1})}}}
+	commitment := make([]byte, 48)
+	commitment[0] = 0xc0
+	versioned := sha256.Sum256(commitment)
+	versioned[0] = BLOB_COMMITMENT_VERSION_KZG
+	blob := BlobTxn{
+		ChainId: types.Uint64Quantity(11155111), Nonce: types.Uint64Quantity(2), MaxPriorityFeePerGas: types.Uint64Quantity(1e9), MaxFeePerGas: types.Uint64Quantity(3e10),
+		Gas: types.Uint64Quantity(21000), To: to, Value: types.Uint64Quantity(0),
+		MaxFeePerBlobGas: types.Uint64Quantity(1e9), BlobVersionedHashes: []types.Hash{types.BytesToHash(versioned[:])},
+	}
+	withSidecar := blob
+	withSidecar.Sidecar = &BlobSidecar{Blobs: []types.Data{make([]byte, 131072)}, Commitments: []types.Data{commitment}, Proofs: []types.Data{make([]byte, 48)}}
+	badSidecar := blob
+	badSidecar.Sidecar = &BlobSidecar{Blobs: []types.Data{{}}, Commitments: []types.Data{make([]byte, 48)}, Proofs: []types.Data{{}}}
+
+	tests := []struct {
+		name    string
+		tx      TypedTransaction
+		wantErr bool
+	}{
+		{
+			name: "access list",
+			tx: AccessListTxn{
+				ChainId: types.Uint64Quantity(1), Nonce: types.Uint64Quantity(0), GasPrice: types.Uint64Quantity(1e9), Gas: types.Uint64Quantity(30000),
+				To: &to, Value: types.Uint64Quantity(1), AccessList: accessList,
+			},
+		},
+		{
+			name: "dynamic fee contract creation",
+			tx: DynamicFeeTxn{
+				ChainId: types.Uint64Quantity(10), Nonce: types.Uint64Quantity(7), MaxPriorityFeePerGas: types.Uint64Quantity(1e6), MaxFeePerGas: types.Uint64Quantity(2e9),
+				Gas: types.Uint64Quantity(1e6), Data: types.MustParseData("0x6000600055"),
+			},
+		},
+		{name: "blob", tx: blob},
+		{name: "blob with sidecar", tx: withSidecar},
+		{name: "blob with mismatched sidecar", tx: badSidecar, wantErr: true},
+		{name: "blob without hashes", tx: BlobTxn{ChainId: types.Uint64Quantity(1), To: to}, wantErr: true},
+		{name: "without chain id", tx: DynamicFeeTxn{Gas: types.Uint64Quantity(21000), To: &to}, wantErr: true},
+		{name: "set code", tx: SetCodeTxn{ChainId: types.Uint64Quantity(1), To: to}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := s.Sign(tt.tx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Signer.Sign() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if signed.Raw[0] != byte(tt.tx.TxType()) {
+				t.Errorf("Signer.Sign() raw type = 0x%x, want 0x%x", signed.Raw[0], tt.tx.TxType())
+			}
+			payload, _ := encodeTransaction(signed.Transaction, true)
+			if signed.Hash != types.BytesToHash(keccak256(payload)) {
+				t.Errorf("Signer.Sign() hash = %s, not the payload hash", signed.Hash)
+			}
+			if _, ok := tt.tx.(BlobTxn); !ok || tt.tx.(BlobTxn).Sidecar == nil {
+				if signed.Raw.Hex() != types.Data(payload).Hex() {
+					t.Errorf("Signer.Sign() raw is not the signed payload")
+				}
+			} else if items, err := rlpListItems(signed.Raw[1:]); err != nil || len(items) != 4 {
+				t.Errorf("Signer.Sign() blob network encoding = %d items, %v", len(items), err)
+			}
+
+			// the sender recovered from the signature is the signer
+			var yParity, r, sv *types.Quantity
+			switch tx := signed.Transaction.(type) {
+			case AccessListTxn:
+				yParity, r, sv = tx.YParity, tx.R, tx.S
+			case DynamicFeeTxn:
+				yParity, r, sv = tx.YParity, tx.R, tx.S
+			case BlobTxn:
+				yParity, r, sv = tx.YParity, tx.R, tx.S
+			}
+			sig := append([]byte{byte(27 + yParity.Uint64())}, r.Big().FillBytes(make([]byte, 32))...)
+			sig = append(sig, sv.Big().FillBytes(make([]byte, 32))...)
+			unsigned, _ := encodeTransaction(tt.tx, false)
+			pub, _, err := ecdsa.RecoverCompact(sig, keccak256(unsigned))
+			if err != nil {
+				t.Fatalf("ecdsa.RecoverCompact() error = %v", err)
+			}
+			if sender := types.BytesToAddress(keccak256(pub.SerializeUncompressed()[1:])[12:]); sender != s.Address() {
+				t.Errorf("recovered sender = %s, want %s", sender, s.Address())
+			}
+			// low s values only (EIP-2)
+			halfOrder := new(big.Int).Rsh(ecdsaCurveOrder, 1)
+			if sv.Big().Cmp(halfOrder) > 0 {
+				t.Errorf("Signer.Sign() s = %s above half the curve order", sv.Hex())
+			}
+		})
+	}
+}
+
+var ecdsaCurveOrder, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
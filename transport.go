@@ -0,0 +1,189 @@
+package goalchemysdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Transport sends one JSON-RPC request and returns its raw result.
+// A JSON-RPC error answered by the node is returned as an *AlchemyApiError.
+type Transport interface {
+	Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error)
+}
+
+// BatchElem is one request of a batch call. Result and Error are filled
+// once the batch has been sent.
+type BatchElem struct {
+	Method string
+	Params []interface{}
+	Result json.RawMessage
+	Error  error
+}
+
+// BatchTransport is a Transport able to send many requests at once.
+// CallBatch only fails when the batch as a whole could not be sent,
+// per request errors are reported in each BatchElem.
+type BatchTransport interface {
+	Transport
+	CallBatch(ctx context.Context, batch []BatchElem) error
+}
+
+// TransportFunc adapts an ordinary function to the Transport interface,
+// handy to serve canned answers in tests.
+type TransportFunc func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error)
+
+func (f TransportFunc) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	return f(ctx, method, params)
+}
+
+var (
+	_ BatchTransport = (*httpTransport)(nil)
+	_ Transport      = TransportFunc(nil)
+	_ Transport      = (*WsClient)(nil)
+)
+
+// transport returns the client Transport, the http one is used by default.
+func (c *AlchemyClient) transport() Transport {
+	if c.Transport != nil {
+		return c.Transport
+	}
+	return &httpTransport{client: c}
+}
+
+// httpTransport posts requests to the Alchemy https endpoint, retrying on
+// rate limits as configured on the client.
+type httpTransport struct {
+	client *AlchemyClient
+}
+
+func (t *httpTransport) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	body, err := json.Marshal(JsonParams[interface{}]{
+		Id:      1,
+		Jsonrpc: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, &AlchemyClientError{fmt.Sprintf("executePost - %s", method), err.Error()}
+	}
+
+	data, err := postWithRetry(ctx, t.client, method, []string{method}, body, func(data AlchemyResponse[json.RawMessage]) *AlchemyApiError {
+		if data.Error != (AlchemyApiError{}) {
+			return &data.Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data.Error != (AlchemyApiError{}) {
+		return nil, &data.Error
+	}
+	return data.Result, nil
+}
+
+// CallBatch sends batch as a JSON-RPC batch array in a single POST. Request
+// ids are the batch indexes so answers are matched whatever order the
+// server sends them in.
+func (t *httpTransport) CallBatch(ctx context.Context, batch []BatchElem) error {
+	reqs := make([]JsonParams[interface{}], len(batch))
+	methods := make([]string, len(batch))
+	for i, elem := range batch {
+		methods[i] = elem.Method
+		reqs[i] = JsonParams[interface{}]{
+			Id:      uint(i + 1),
+			Jsonrpc: "2.0",
+			Method:  elem.Method,
+			Params:  elem.Params,
+		}
+	}
+
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return &AlchemyClientError{"CallBatch", err.Error()}
+	}
+
+	raw, err := postWithRetry(ctx, t.client, batchMethod(batch), methods, body, batchRejection)
+	if err != nil {
+		return err
+	}
+	if apiErr := batchRejection(raw); apiErr != nil {
+		return apiErr
+	}
+
+	var answers []AlchemyResponse[json.RawMessage]
+	if err := json.Unmarshal(raw, &answers); err != nil {
+		return &AlchemyClientError{"CallBatch", err.Error()}
+	}
+
+	answered := make([]bool, len(batch))
+	for i := range answers {
+		idx := int(answers[i].Id) - 1
+		if idx < 0 || idx >= len(batch) || answered[idx] {
+			return &AlchemyClientError{"CallBatch", fmt.Sprintf("unexpected response id %d", answers[i].Id)}
+		}
+		answered[idx] = true
+		if answers[i].Error != (AlchemyApiError{}) {
+			apiErr := answers[i].Error
+			batch[idx].Error = &apiErr
+			continue
+		}
+		batch[idx].Result = answers[i].Result
+	}
+	for i := range batch {
+		if !answered[i] {
+			apiErr := ErrorMissingBatchResponse
+			batch[i].Error = &apiErr
+		}
+	}
+	return nil
+}
+
+// batchRejection returns the error of a batch rejected as a whole, which is
+// answered with a single error object instead of an array.
+func batchRejection(raw json.RawMessage) *AlchemyApiError {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil
+	}
+	var single AlchemyResponse[json.RawMessage]
+	if err := json.Unmarshal(trimmed, &single); err != nil || single.Error == (AlchemyApiError{}) {
+		return nil
+	}
+	return &single.Error
+}
+
+// batchMethod names a batch in logs after the methods it contains.
+func batchMethod(batch []BatchElem) string {
+	methods := make([]string, 0, len(batch))
+	seen := make(map[string]bool)
+	for _, elem := range batch {
+		if !seen[elem.Method] {
+			seen[elem.Method] = true
+			methods = append(methods, elem.Method)
+		}
+	}
+	return "batch[" + strings.Join(methods, ",") + "]"
+}
+
+// callBatch sends batch through t, one request at a time when t cannot
+// batch.
+func callBatch(ctx context.Context, t Transport, batch []BatchElem) error {
+	if bt, ok := t.(BatchTransport); ok {
+		return bt.CallBatch(ctx, batch)
+	}
+	for i := range batch {
+		result, err := t.Call(ctx, batch[i].Method, batch[i].Params)
+		var apiErr *AlchemyApiError
+		if err != nil && !errors.As(err, &apiErr) {
+			return err
+		}
+		batch[i].Result = result
+		batch[i].Error = err
+	}
+	return nil
+}
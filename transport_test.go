@@ -0,0 +1,210 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// fakeTransport answers every method from results, a missing method is
+// answered with ErrorWrongMethod.
+func fakeTransport(results map[string]string) TransportFunc {
+	return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		result, ok := results[method]
+		if !ok {
+			apiErr := ErrorWrongMethod(method)
+			return nil, &apiErr
+		}
+		return json.RawMessage(result), nil
+	}
+}
+
+// fakeAccount is the state of an address on a fakeNode.
+type fakeAccount struct {
+	code    string                    // eth_getCode result, no code when empty
+	storage map[types.Hash]types.Hash // eth_getStorageAt results by slot, zero when missing
+	calls   map[string]types.Hash     // eth_call results by call data, empty when missing
+}
+
+// fakeNode is a Transport answering state, logs and transaction queries
+// the way a node does: addresses it does not know have no code nor storage
+// and calls to them return nothing.
+type fakeNode struct {
+	accounts map[types.Address]fakeAccount
+	logs     LogsResults
+	txns     map[types.Hash]string // eth_getTransactionByHash results by hash
+}
+
+func (fn fakeNode) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	var args []json.RawMessage
+	if err := json.Unmarshal(encoded, &args); err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case "eth_getCode":
+		var address types.Address
+		if err := decodeArgs(args, &address); err != nil {
+			return nil, err
+		}
+		code := fn.accounts[address].code
+		if code == "" {
+			code = "0x"
+		}
+		return json.Marshal(code)
+	case "eth_getStorageAt":
+		var address types.Address
+		var slot types.Hash
+		if err := decodeArgs(args, &address, &slot); err != nil {
+			return nil, err
+		}
+		return json.Marshal(fn.accounts[address].storage[slot])
+	case "eth_call":
+		var txn struct {
+			To   *types.Address `json:"to"`
+			Data types.Data     `json:"data"`
+		}
+		if err := decodeArgs(args, &txn); err != nil {
+			return nil, err
+		}
+		if txn.To != nil {
+			if result, ok := fn.accounts[*txn.To].calls[txn.Data.Hex()]; ok {
+				return json.Marshal(result)
+			}
+		}
+		return json.Marshal("0x")
+	case "eth_getLogs":
+		var filter struct {
+			BlockHash *types.Hash    `json:"blockHash"`
+			Address   *types.Address `json:"address"`
+		}
+		if err := decodeArgs(args, &filter); err != nil {
+			return nil, err
+		}
+		logs := LogsResults{}
+		for _, l := range fn.logs {
+			if (filter.BlockHash == nil || *filter.BlockHash == l.BlockHash) &&
+				(filter.Address == nil || *filter.Address == l.Address) {
+				logs = append(logs, l)
+			}
+		}
+		return json.Marshal(logs)
+	case "eth_getTransactionByHash":
+		var hash types.Hash
+		if err := decodeArgs(args, &hash); err != nil {
+			return nil, err
+		}
+		if len(args) > 1 {
+			apiErr := ErrorTooManyArguments
+			return nil, &apiErr
+		}
+		txn, ok := fn.txns[hash]
+		if !ok {
+			return json.RawMessage("null"), nil
+		}
+		return json.RawMessage(txn), nil
+	}
+	apiErr := ErrorWrongMethod(method)
+	return nil, &apiErr
+}
+
+// decodeArgs decodes the leading args of a call into dst, failing as a
+// node does when some are missing.
+func decodeArgs(args []json.RawMessage, dst ...interface{}) error {
+	if len(args) < len(dst) {
+		apiErr := ErrorExpectedAtLeastOneArgument
+		return &apiErr
+	}
+	for i := range dst {
+		if err := json.Unmarshal(args[i], dst[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAlchemyClient_executePost_Transport(t *testing.T) {
+	c := &AlchemyClient{
+		Transport: fakeTransport(map[string]string{
+			"eth_getCode": `"0x363d3d373d3d3d363d"`,
+			"eth_bad":     `{"not":"a string"}`,
+		}),
+	}
+	tests := []struct {
+		name    string
+		method  string
+		want    *AlchemyResponse[string]
+		wantErr bool
+	}{
+		{
+			name:   "result decoded",
+			method: "eth_getCode",
+			want:   &AlchemyResponse[string]{Id: 3, Jsonrpc: "2.0", Result: "0x363d3d373d3d3d363d"},
+		},
+		{
+			name:    "api error returned and kept in response",
+			method:  "eth_wrong_method",
+			want:    &AlchemyResponse[string]{Id: 3, Jsonrpc: "2.0", Error: ErrorWrongMethod("eth_wrong_method")},
+			wantErr: true,
+		},
+		{
+			name:    "undecodable result",
+			method:  "eth_bad",
+			want:    &AlchemyResponse[string]{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := JsonParams[string]{Id: 3, Jsonrpc: "2.0", Method: tt.method, Params: []string{"0x01", "latest"}}
+			got, err := executePost[string, string](context.Background(), c, j)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("executePost() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("executePost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_callBatch_sequentialFallback(t *testing.T) {
+	calls := 0
+	tr := TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		calls++
+		return fakeTransport(map[string]string{"eth_blockNumber": `"0x10"`}).Call(ctx, method, params)
+	})
+	batch := []BatchElem{
+		{Method: "eth_blockNumber"},
+		{Method: "eth_wrong_method"},
+	}
+	if err := callBatch(context.Background(), tr, batch); err != nil {
+		t.Fatalf("callBatch() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("callBatch() sent %d calls, want 2", calls)
+	}
+	if string(batch[0].Result) != `"0x10"` || batch[0].Error != nil {
+		t.Errorf("callBatch() item 0 = %s, %v", batch[0].Result, batch[0].Error)
+	}
+	var apiErr *AlchemyApiError
+	if !errors.As(batch[1].Error, &apiErr) || *apiErr != ErrorWrongMethod("eth_wrong_method") {
+		t.Errorf("callBatch() item 1 error = %v", batch[1].Error)
+	}
+
+	failing := TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		return nil, context.Canceled
+	})
+	if err := callBatch(context.Background(), failing, batch); !errors.Is(err, context.Canceled) {
+		t.Errorf("callBatch() error = %v, want %v", err, context.Canceled)
+	}
+}
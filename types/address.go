@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const ADDRESS_LENGTH = 20
+
+// Address is a 20 bytes account address, written out with its EIP-55
+// checksum.
+type Address [ADDRESS_LENGTH]byte
+
+// ParseAddress reads a 0x prefixed address. A mixed case address must
+// carry a valid EIP-55 checksum, all lower or upper case ones are taken
+// as is.
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	if err := decodeFixed("address", s, a[:]); err != nil {
+		return Address{}, err
+	}
+	digits := s[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && a.Hex()[2:] != digits {
+		return Address{}, fmt.Errorf("invalid address %q: bad EIP-55 checksum", s)
+	}
+	return a, nil
+}
+
+// MustParseAddress is ParseAddress panicking on error, for constants.
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// BytesToAddress keeps the last 20 bytes of b, left padding shorter ones.
+func BytesToAddress(b []byte) Address {
+	var a Address
+	if len(b) > len(a) {
+		b = b[len(b)-len(a):]
+	}
+	copy(a[len(a)-len(b):], b)
+	return a
+}
+
+// Hex returns the EIP-55 checksummed address.
+func (a Address) Hex() string {
+	lower := hex.EncodeToString(a[:])
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	hash := h.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		// a letter is upper cased when its hash nibble is 8 or more
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+func (a Address) String() string {
+	return a.Hex()
+}
+
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+func (a Address) Bytes() []byte {
+	return a[:]
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+func (a *Address) UnmarshalText(b []byte) error {
+	parsed, err := ParseAddress(string(b))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{"checksummed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"checksummed all caps letters", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", false},
+		{"lower case", "0xdbf03b407c01e7cd3cbea99509d93f8dddc8c6fb", "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", false},
+		{"upper case", "0xD1220A0CF47C7B9BE7A2E6BA89F429762E7B9ADB", "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", false},
+		{"bad checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", "", true},
+		{"too short", "0xdead3fd06D57D140f6ad8C2913DbE87fdecDd5F", "", true},
+		{"no prefix", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "", true},
+		{"not hex", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAddress(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Hex() != tt.want {
+				t.Errorf("ParseAddress().Hex() = %v, want %v", got.Hex(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAddress_JSON(t *testing.T) {
+	type wrapper struct {
+		To   Address  `json:"to"`
+		From *Address `json:"from,omitempty"`
+	}
+	var w wrapper
+	if err := json.Unmarshal([]byte(`{"to":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}`), &w); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"to":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+	if err := json.Unmarshal([]byte(`{"to":"0x1234"}`), &w); err == nil || !strings.Contains(err.Error(), "20") {
+		t.Errorf("json.Unmarshal() short address error = %v", err)
+	}
+}
+
+func TestBytesToAddress(t *testing.T) {
+	word := MustParseHash("0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	if got := BytesToAddress(word[:]); got != MustParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed") {
+		t.Errorf("BytesToAddress() = %v", got)
+	}
+	if got := BytesToAddress([]byte{1}); got.Hex() != "0x0000000000000000000000000000000000000001" {
+		t.Errorf("BytesToAddress() = %v", got)
+	}
+}
@@ -0,0 +1,45 @@
+package types
+
+import "encoding/hex"
+
+// Data is an unformatted byte string such as call data or contract code.
+type Data []byte
+
+// ParseData reads a 0x prefixed hex string, "0x" is empty data.
+func ParseData(s string) (Data, error) {
+	b, err := decodeHex("data", s)
+	if err != nil {
+		return nil, err
+	}
+	return Data(b), nil
+}
+
+// MustParseData is ParseData panicking on error, for constants.
+func MustParseData(s string) Data {
+	d, err := ParseData(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Data) Hex() string {
+	return "0x" + hex.EncodeToString(d)
+}
+
+func (d Data) String() string {
+	return d.Hex()
+}
+
+func (d Data) MarshalText() ([]byte, error) {
+	return []byte(d.Hex()), nil
+}
+
+func (d *Data) UnmarshalText(b []byte) error {
+	parsed, err := ParseData(string(b))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
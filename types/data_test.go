@@ -0,0 +1,46 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestData_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Data
+		wantErr bool
+	}{
+		{"bytes", `"0x363d3d37"`, Data{0x36, 0x3d, 0x3d, 0x37}, false},
+		{"empty", `"0x"`, Data{}, false},
+		{"odd length", `"0x363"`, nil, true},
+		{"no prefix", `"363d"`, nil, true},
+		{"not a string", `12`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Data
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("json.Unmarshal() = %#v, want %#v", got, tt.want)
+			}
+			b, err := json.Marshal(got)
+			if err != nil || string(b) != tt.json {
+				t.Errorf("json.Marshal() = %s, %v, want %s", b, err, tt.json)
+			}
+		})
+	}
+
+	var nilData Data
+	if b, _ := json.Marshal(nilData); string(b) != `"0x"` {
+		t.Errorf("json.Marshal() nil = %s, want \"0x\"", b)
+	}
+}
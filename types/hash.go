@@ -0,0 +1,66 @@
+package types
+
+import "encoding/hex"
+
+const HASH_LENGTH = 32
+
+// Hash is a 32 bytes keccak hash, of a block, a transaction or a topic,
+// also used for storage slots.
+type Hash [HASH_LENGTH]byte
+
+// ParseHash reads a 0x prefixed 32 bytes hash.
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	if err := decodeFixed("hash", s, h[:]); err != nil {
+		return Hash{}, err
+	}
+	return h, nil
+}
+
+// MustParseHash is ParseHash panicking on error, for constants.
+func MustParseHash(s string) Hash {
+	h, err := ParseHash(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// BytesToHash keeps the last 32 bytes of b, left padding shorter ones.
+func BytesToHash(b []byte) Hash {
+	var h Hash
+	if len(b) > len(h) {
+		b = b[len(b)-len(h):]
+	}
+	copy(h[len(h)-len(b):], b)
+	return h
+}
+
+func (h Hash) Hex() string {
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+func (h Hash) IsZero() bool {
+	return h == Hash{}
+}
+
+func (h Hash) Bytes() []byte {
+	return h[:]
+}
+
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(h.Hex()), nil
+}
+
+func (h *Hash) UnmarshalText(b []byte) error {
+	parsed, err := ParseHash(string(b))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{"hash", "0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178", false},
+		{"upper case", "0X2D6DA6EA7D7D7D1CA72576DC457A2B6F59FB798566FB97492B3F2835B0A59178", false},
+		{"too short", "0xdeadbeef", true},
+		{"odd length", "0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a5917", true},
+		{"empty", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHash(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHash_JSON(t *testing.T) {
+	in := `["0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178",null]`
+	var topics []*Hash
+	if err := json.Unmarshal([]byte(in), &topics); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if topics[1] != nil || topics[0].Hex() != "0x2d6da6ea7d7d7d1ca72576dc457a2b6f59fb798566fb97492b3f2835b0a59178" {
+		t.Errorf("json.Unmarshal() = %v", topics)
+	}
+	b, err := json.Marshal(topics)
+	if err != nil || string(b) != in {
+		t.Errorf("json.Marshal() = %s, %v, want %s", b, err, in)
+	}
+}
@@ -0,0 +1,34 @@
+// Package types holds the hex encoded values of the Ethereum JSON-RPC api:
+// addresses, hashes, quantities and byte strings. Each one validates its
+// input when parsed or decoded from json.
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// decodeHex decodes a 0x prefixed hex string of an even length.
+func decodeHex(kind string, s string) ([]byte, error) {
+	if len(s) < 2 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return nil, fmt.Errorf("invalid %s %q: missing 0x prefix", kind, s)
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", kind, s, err)
+	}
+	return b, nil
+}
+
+// decodeFixed decodes a 0x prefixed hex string of exactly len(out) bytes.
+func decodeFixed(kind string, s string, out []byte) error {
+	b, err := decodeHex(kind, s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(out) {
+		return fmt.Errorf("invalid %s %q: %d bytes, want %d", kind, s, len(b), len(out))
+	}
+	copy(out, b)
+	return nil
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Quantity is an integer encoded as hex without leading zeros, "0x0" for
+// zero. It is used by pointer, as *big.Int is.
+type Quantity big.Int
+
+// NewQuantity returns x as a Quantity, sharing its value.
+func NewQuantity(x *big.Int) *Quantity {
+	return (*Quantity)(x)
+}
+
+// Uint64Quantity returns n as a Quantity.
+func Uint64Quantity(n uint64) *Quantity {
+	return (*Quantity)(new(big.Int).SetUint64(n))
+}
+
+// ParseQuantity reads a 0x prefixed hex integer. Quantities are never
+// negative.
+func ParseQuantity(s string) (*Quantity, error) {
+	if len(s) < 3 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return nil, fmt.Errorf("invalid quantity %q: want 0x followed by hex digits", s)
+	}
+	for _, c := range s[2:] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return nil, fmt.Errorf("invalid quantity %q: not a hex digit %q", s, c)
+		}
+	}
+	x, _ := new(big.Int).SetString(s[2:], 16)
+	return (*Quantity)(x), nil
+}
+
+// MustParseQuantity is ParseQuantity panicking on error, for constants.
+func MustParseQuantity(s string) *Quantity {
+	q, err := ParseQuantity(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Big returns the value as a *big.Int, sharing it. A nil Quantity is nil.
+func (q *Quantity) Big() *big.Int {
+	return (*big.Int)(q)
+}
+
+// Uint64 returns the value, truncated if it does not fit.
+func (q *Quantity) Uint64() uint64 {
+	if q == nil {
+		return 0
+	}
+	return q.Big().Uint64()
+}
+
+func (q *Quantity) Hex() string {
+	if q == nil {
+		return "0x0"
+	}
+	return fmt.Sprintf("0x%x", q.Big())
+}
+
+func (q *Quantity) String() string {
+	if q == nil {
+		return "<nil>"
+	}
+	return q.Big().String()
+}
+
+func (q *Quantity) MarshalText() ([]byte, error) {
+	if q != nil && q.Big().Sign() < 0 {
+		return nil, fmt.Errorf("invalid quantity %s: negative", q.Big())
+	}
+	return []byte(q.Hex()), nil
+}
+
+func (q *Quantity) UnmarshalText(b []byte) error {
+	parsed, err := ParseQuantity(string(b))
+	if err != nil {
+		return err
+	}
+	*q = *parsed
+	return nil
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{"zero", "0x0", "0x0", false},
+		{"number", "0x4116290", "0x4116290", false},
+		{"leading zeros made minimal", "0x00ff", "0xff", false},
+		{"above 64 bits", "0x1000000000000000000000000", "0x1000000000000000000000000", false},
+		{"empty", "0x", "", true},
+		{"no prefix", "12", "", true},
+		{"negative", "0x-1", "", true},
+		{"not hex", "0xfg", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuantity(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuantity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Hex() != tt.want {
+				t.Errorf("ParseQuantity().Hex() = %v, want %v", got.Hex(), tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantity_JSON(t *testing.T) {
+	type wrapper struct {
+		Gas   *Quantity `json:"gas"`
+		Value *Quantity `json:"value,omitempty"`
+	}
+	var w wrapper
+	if err := json.Unmarshal([]byte(`{"gas":"0xe5398"}`), &w); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if w.Gas.Uint64() != 0xe5398 || w.Value != nil {
+		t.Errorf("json.Unmarshal() = %v, %v", w.Gas, w.Value)
+	}
+	w.Value = NewQuantity(big.NewInt(0))
+	b, err := json.Marshal(w)
+	if want := `{"gas":"0xe5398","value":"0x0"}`; err != nil || string(b) != want {
+		t.Errorf("json.Marshal() = %s, %v, want %s", b, err, want)
+	}
+	if _, err := json.Marshal(NewQuantity(big.NewInt(-1))); err == nil {
+		t.Errorf("json.Marshal() negative error = nil")
+	}
+}
@@ -0,0 +1,141 @@
+package goalchemysdk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+const (
+	POLL_INTERVAL_DEFAULT = 2 * time.Second
+	// polls a transaction may go unseen before it is reported dropped, as
+	// nodes behind the api do not share the same mempool
+	DROPPED_AFTER_POLLS = 5
+)
+
+var (
+	// ErrTransactionDropped is returned by WaitForTransaction when the
+	// transaction left the mempool without being mined.
+	ErrTransactionDropped = errors.New("transaction dropped")
+	// ErrTransactionReplaced is returned by WaitForTransaction when another
+	// transaction with the same sender and nonce was mined.
+	ErrTransactionReplaced = errors.New("transaction replaced")
+)
+
+// WaitForTransaction polls until the transaction hash is mined with
+// confirmations blocks on top of its own one included, and returns its
+// receipt. The receipt of a reverted transaction is returned without
+// error, see Receipt.Succeeded.
+// A transaction whose block is re-orged out is waited for again. One that
+// stays unknown is reported with ErrTransactionDropped, one whose nonce
+// was used by another transaction with ErrTransactionReplaced.
+func (c *AlchemyClient) WaitForTransaction(ctx context.Context, hash types.Hash, confirmations uint64) (*Receipt, error) {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	ticker := time.NewTicker(c.pollEvery())
+	defer ticker.Stop()
+
+	var sent *TransactionJson // last seen, for its sender and nonce
+	missed := 0
+	for {
+		receipt, confirmed, err := c.minedReceipt(ctx, hash, confirmations)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed {
+			return receipt, nil
+		}
+		if receipt == nil {
+			pending, err := c.pendingTransaction(ctx, hash, sent)
+			if err != nil {
+				return nil, err
+			}
+			if pending != nil {
+				sent, missed = pending, 0
+			} else {
+				missed++
+			}
+			if missed >= DROPPED_AFTER_POLLS {
+				return nil, ErrTransactionDropped
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// minedReceipt returns the receipt of hash, nil while it is not mined or
+// when its block is no longer canonical, and whether it has confirmations.
+func (c *AlchemyClient) minedReceipt(ctx context.Context, hash types.Hash, confirmations uint64) (*Receipt, bool, error) {
+	resp, err := c.Eth_getTransactionReceipt(ctx, hash)
+	if err != nil || resp.Result == nil {
+		return nil, false, err
+	}
+	receipt := resp.Result
+	// the receipt may come from a block re-orged out since
+	mined := receipt.BlockNumber.Uint64()
+	block, err := c.Eth_getBlockByNumber(ctx, BlockNumber(mined), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if block.Result == nil || block.Result.Hash != receipt.BlockHash {
+		return nil, false, nil
+	}
+	head, err := c.Eth_blockNumber(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return receipt, uint64(head.Result) >= mined && uint64(head.Result)-mined+1 >= confirmations, nil
+}
+
+// pendingTransaction returns the transaction hash while it is known, nil
+// once it is not. last is the transaction as last seen: when its nonce was
+// used by another mined transaction, it was replaced.
+func (c *AlchemyClient) pendingTransaction(ctx context.Context, hash types.Hash, last *TransactionJson) (*TransactionJson, error) {
+	txn, err := c.Eth_getTransactionByHash(ctx, []TransactionByHashParam{hash})
+	if err != nil {
+		return nil, err
+	}
+	if !txn.Result.Hash.IsZero() {
+		return &txn.Result, nil
+	}
+	if last == nil {
+		return nil, nil
+	}
+	used, err := c.nonceUsed(ctx, last)
+	if err != nil || !used {
+		return nil, err
+	}
+	// the transaction itself may have been mined in between
+	mined, err := c.Eth_getTransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if mined.Result == nil {
+		return nil, ErrTransactionReplaced
+	}
+	return last, nil
+}
+
+// nonceUsed tells if the nonce of txn was used by a mined transaction.
+func (c *AlchemyClient) nonceUsed(ctx context.Context, txn *TransactionJson) (bool, error) {
+	count, err := c.Eth_getTransactionCount(ctx, txn.From, LATEST)
+	if err != nil {
+		return false, err
+	}
+	return count.Result.Big().Cmp(orZero(txn.Nonce)) > 0, nil
+}
+
+func (c *AlchemyClient) pollEvery() time.Duration {
+	if c.pollInterval <= 0 {
+		return POLL_INTERVAL_DEFAULT
+	}
+	return c.pollInterval
+}
@@ -0,0 +1,191 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// chainState is what the fake chain answers during a poll.
+type chainState struct {
+	head      uint64
+	pending   bool   // transaction known by the node
+	count     uint64 // transaction count of the sender
+	minedIn   uint64 // block of the receipt, 0 when not mined
+	canonical map[uint64]uint64
+}
+
+func blockHashOf(n uint64) string {
+	return fmt.Sprintf("0x%064x", n)
+}
+
+// fakeChain answers from the state of the current poll, a poll starting
+// with each receipt query.
+func fakeChain(state func(poll int) chainState) (*AlchemyClient, *int) {
+	var mu sync.Mutex
+	polls := 0
+	txHash := blockHashOf(0xaa)
+	c := &AlchemyClient{
+		pollInterval: time.Millisecond,
+		Transport: TransportFunc(func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if method == "eth_getTransactionReceipt" {
+				polls++
+			}
+			st := state(polls - 1)
+			switch method {
+			case "eth_getTransactionReceipt":
+				if st.minedIn == 0 {
+					return json.RawMessage(`null`), nil
+				}
+				return json.RawMessage(fmt.Sprintf(`{"transactionHash":"%s","blockNumber":"0x%x","blockHash":"%s","status":"0x1"}`, txHash, st.minedIn, blockHashOf(st.minedIn))), nil
+			case "eth_blockNumber":
+				return json.RawMessage(fmt.Sprintf(`"0x%x"`, st.head)), nil
+			case "eth_getBlockByNumber":
+				raw, _ := json.Marshal(params[0])
+				var q types.Quantity
+				if err := json.Unmarshal(raw, &q); err != nil {
+					return nil, err
+				}
+				n := q.Uint64()
+				hash := blockHashOf(n)
+				if h, ok := st.canonical[n]; ok {
+					hash = blockHashOf(h)
+				}
+				return json.RawMessage(fmt.Sprintf(`{"number":"0x%x","hash":"%s","transactions":[]}`, n, hash)), nil
+			case "eth_getTransactionByHash":
+				if !st.pending {
+					return json.RawMessage(`null`), nil
+				}
+				return json.RawMessage(fmt.Sprintf(`{"hash":"%s","from":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed","nonce":"0x4"}`, txHash)), nil
+			case "eth_getTransactionCount":
+				return json.RawMessage(fmt.Sprintf(`"0x%x"`, st.count)), nil
+			}
+			return nil, fmt.Errorf("unexpected method %s", method)
+		}),
+	}
+	return c, &polls
+}
+
+func TestAlchemyClient_WaitForTransaction(t *testing.T) {
+	hash := types.MustParseHash(blockHashOf(0xaa))
+	tests := []struct {
+		name          string
+		confirmations uint64
+		state         func(poll int) chainState
+		wantBlock     uint64
+		wantErr       error
+	}{
+		{
+			name:          "pending then confirmed",
+			confirmations: 3,
+			state: func(poll int) chainState {
+				if poll < 2 {
+					return chainState{head: 8 + uint64(poll), pending: true, count: 4}
+				}
+				return chainState{head: 8 + uint64(poll), pending: true, count: 5, minedIn: 10}
+			},
+			wantBlock: 10,
+		},
+		{
+			name:          "zero confirmations waits for the block",
+			confirmations: 0,
+			state: func(poll int) chainState {
+				if poll < 1 {
+					return chainState{head: 9, pending: true, count: 4}
+				}
+				return chainState{head: 10, pending: true, count: 5, minedIn: 10}
+			},
+			wantBlock: 10,
+		},
+		{
+			name:          "re-orged then mined again",
+			confirmations: 2,
+			state: func(poll int) chainState {
+				head := 10 + uint64(poll)
+				switch {
+				case poll == 0:
+					return chainState{head: head, pending: true, count: 5, minedIn: 10}
+				case poll < 3:
+					// the receipt still points at the block re-orged out
+					return chainState{head: head, pending: true, count: 4, minedIn: 10, canonical: map[uint64]uint64{10: 0xbb}}
+				default:
+					return chainState{head: head, pending: true, count: 5, minedIn: 11, canonical: map[uint64]uint64{10: 0xbb}}
+				}
+			},
+			wantBlock: 11,
+		},
+		{
+			name:          "replaced",
+			confirmations: 1,
+			state: func(poll int) chainState {
+				if poll < 2 {
+					return chainState{head: 10, pending: true, count: 4}
+				}
+				return chainState{head: 11, count: 5}
+			},
+			wantErr: ErrTransactionReplaced,
+		},
+		{
+			name:          "dropped",
+			confirmations: 1,
+			state: func(poll int) chainState {
+				if poll < 1 {
+					return chainState{head: 10, pending: true, count: 4}
+				}
+				return chainState{head: 10 + uint64(poll), count: 4}
+			},
+			wantErr: ErrTransactionDropped,
+		},
+		{
+			name:          "never seen",
+			confirmations: 1,
+			state: func(poll int) chainState {
+				return chainState{head: 10, count: 4}
+			},
+			wantErr: ErrTransactionDropped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := fakeChain(tt.state)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			got, err := c.WaitForTransaction(ctx, hash, tt.confirmations)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WaitForTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.BlockNumber.Uint64() != tt.wantBlock {
+				t.Errorf("WaitForTransaction() mined in %d, want %d", got.BlockNumber.Uint64(), tt.wantBlock)
+			}
+			if got.TransactionHash != hash {
+				t.Errorf("WaitForTransaction() receipt of %s, want %s", got.TransactionHash, hash)
+			}
+		})
+	}
+}
+
+func TestAlchemyClient_WaitForTransaction_canceled(t *testing.T) {
+	c, polls := fakeChain(func(poll int) chainState {
+		return chainState{head: 10, pending: true, count: 4}
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.WaitForTransaction(ctx, types.MustParseHash(blockHashOf(0xaa)), 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForTransaction() error = %v, want context.DeadlineExceeded", err)
+	}
+	if *polls < 2 {
+		t.Errorf("WaitForTransaction() polled %d times, want it to keep polling while pending", *polls)
+	}
+}
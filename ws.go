@@ -0,0 +1,382 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WS_RECONNECT_DELAY     = 100 * time.Millisecond
+	WS_RECONNECT_MAX_DELAY = 30 * time.Second
+)
+
+// ErrWsClosed is returned by calls made on, or pending when, a closed
+// websocket client.
+var ErrWsClosed = errors.New("websocket client closed")
+
+// errWsDisconnected fails calls pending when the connection drops, or
+// made until it is replaced; the client reconnects on its own but the
+// answer to those calls is lost.
+var errWsDisconnected = errors.New("websocket connection lost")
+
+// wsMessage is any message received on the websocket: a call answer has an
+// id, a subscription notification has a method and params.
+type wsMessage struct {
+	Id      *uint            `json:"id,omitempty"`
+	Jsonrpc string           `json:"jsonrpc"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *AlchemyApiError `json:"error,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  *wsNotification  `json:"params,omitempty"`
+
+	err error // set instead of an answer when the call is failed locally
+}
+
+// wsCall is a request waiting for its answer. An eth_subscribe call carries
+// its subscription so the reader registers it before handling any further
+// message, the first notification often follows the answer immediately.
+type wsCall struct {
+	answer chan wsMessage
+	sub    *wsSubscription
+}
+
+type wsNotification struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// WsClient is a websocket connection to the Alchemy api. It reconnects on
+// connection loss and resubscribes every live subscription.
+type WsClient struct {
+	url string
+
+	writeMu sync.Mutex // serialises writes on conn
+
+	mu      sync.Mutex
+	conn    *websocket.Conn // nil while reconnecting
+	nextId  uint
+	pending map[uint]*wsCall
+	subs    map[string]*wsSubscription // keyed by server subscription id
+	resubs  []*wsSubscription          // cut off while resubscribing, replayed by the next reconnect
+	closed  bool
+	done    chan struct{}
+}
+
+func (c *AlchemyClient) getWsUrl() (string, error) {
+	url, err := c.getApiUrl()
+	if err != nil && url == "" {
+		return "", &AlchemyClientError{"getWsUrl()", err.Error()}
+	}
+	if strings.HasPrefix(url, "https://") {
+		return "wss://" + strings.TrimPrefix(url, "https://"), nil
+	}
+	if strings.HasPrefix(url, "http://") {
+		return "ws://" + strings.TrimPrefix(url, "http://"), nil
+	}
+	return url, nil
+}
+
+// DialWs opens a websocket connection to the client network.
+func (c *AlchemyClient) DialWs(ctx context.Context) (*WsClient, error) {
+	url, err := c.getWsUrl()
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, &AlchemyClientError{"DialWs", err.Error()}
+	}
+	ws := &WsClient{
+		url:     url,
+		conn:    conn,
+		pending: make(map[uint]*wsCall),
+		subs:    make(map[string]*wsSubscription),
+		done:    make(chan struct{}),
+	}
+	go ws.readLoop(conn)
+	return ws, nil
+}
+
+// Close closes the connection, fails pending calls and ends every
+// subscription.
+func (ws *WsClient) Close() error {
+	ws.mu.Lock()
+	if ws.closed {
+		ws.mu.Unlock()
+		return nil
+	}
+	ws.closed = true
+	close(ws.done)
+	conn := ws.conn
+	subs := ws.takeSubs()
+	ws.failPending(ErrWsClosed)
+	ws.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.end(ErrWsClosed)
+	}
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+// Call sends a JSON-RPC request and waits for its answer, WsClient can be
+// used as the Transport of an AlchemyClient.
+func (ws *WsClient) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	return ws.callFor(ctx, nil, method, params)
+}
+
+func (ws *WsClient) callFor(ctx context.Context, sub *wsSubscription, method string, params []interface{}) (json.RawMessage, error) {
+	ws.mu.Lock()
+	if ws.closed {
+		ws.mu.Unlock()
+		return nil, ErrWsClosed
+	}
+	if ws.conn == nil {
+		ws.mu.Unlock()
+		return nil, errWsDisconnected
+	}
+	ws.nextId++
+	id := ws.nextId
+	answer := make(chan wsMessage, 1)
+	ws.pending[id] = &wsCall{answer: answer, sub: sub}
+	conn := ws.conn
+	ws.mu.Unlock()
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	req := JsonParams[interface{}]{
+		Id:      id,
+		Jsonrpc: "2.0",
+		Method:  method,
+		Params:  params,
+	}
+	ws.writeMu.Lock()
+	err := conn.WriteJSON(req)
+	ws.writeMu.Unlock()
+	if err != nil {
+		ws.forget(id)
+		// the reader notices the drop too and reconnects
+		return nil, fmt.Errorf("%w: %s: %v", errWsDisconnected, method, err)
+	}
+
+	select {
+	case msg := <-answer:
+		if msg.err != nil {
+			return nil, msg.err
+		}
+		if msg.Error != nil && msg.Error.Code != 0 {
+			return nil, msg.Error
+		}
+		return msg.Result, nil
+	case <-ctx.Done():
+		ws.forget(id)
+		return nil, ctx.Err()
+	}
+}
+
+func (ws *WsClient) forget(id uint) {
+	ws.mu.Lock()
+	delete(ws.pending, id)
+	ws.mu.Unlock()
+}
+
+// failPending must be called with ws.mu held.
+func (ws *WsClient) failPending(err error) {
+	for id, pending := range ws.pending {
+		pending.answer <- wsMessage{err: err}
+		delete(ws.pending, id)
+	}
+}
+
+// takeSubs returns every subscription to replay, routed or cut off while
+// resubscribing, and forgets them. It must be called with ws.mu held.
+func (ws *WsClient) takeSubs() []*wsSubscription {
+	subs := ws.resubs
+	for _, sub := range ws.subs {
+		subs = append(subs, sub)
+	}
+	ws.subs = make(map[string]*wsSubscription)
+	ws.resubs = nil
+	return subs
+}
+
+func (ws *WsClient) readLoop(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			ws.reconnect(conn)
+			return
+		}
+		var msg wsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.Id != nil {
+			ws.mu.Lock()
+			pending, ok := ws.pending[*msg.Id]
+			delete(ws.pending, *msg.Id)
+			if ok && pending.sub != nil {
+				var id string
+				if json.Unmarshal(msg.Result, &id) == nil && id != "" {
+					if pending.sub.register(id) {
+						ws.subs[id] = pending.sub
+					} else {
+						// unsubscribed while eth_subscribe was in flight
+						go ws.drop(id)
+					}
+				}
+			}
+			ws.mu.Unlock()
+			if ok {
+				pending.answer <- msg
+			}
+			continue
+		}
+		if msg.Method == "eth_subscription" && msg.Params != nil {
+			ws.mu.Lock()
+			sub, ok := ws.subs[msg.Params.Subscription]
+			ws.mu.Unlock()
+			if ok {
+				sub.deliver(msg.Params.Result)
+			}
+		}
+	}
+}
+
+// reconnect replaces a dropped connection with exponential back off, then
+// replays every eth_subscribe so subscribers keep receiving events.
+func (ws *WsClient) reconnect(dropped *websocket.Conn) {
+	ws.mu.Lock()
+	if ws.closed || ws.conn != dropped {
+		ws.mu.Unlock()
+		return
+	}
+	ws.failPending(errWsDisconnected)
+	ws.conn = nil
+	ws.mu.Unlock()
+	dropped.Close()
+
+	delay := WS_RECONNECT_DELAY
+	for {
+		select {
+		case <-ws.done:
+			return
+		case <-time.After(delay):
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), WS_RECONNECT_MAX_DELAY)
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, ws.url, nil)
+		cancel()
+		if err == nil {
+			ws.mu.Lock()
+			if ws.closed {
+				ws.mu.Unlock()
+				conn.Close()
+				return
+			}
+			ws.conn = conn
+			subs := ws.takeSubs()
+			ws.mu.Unlock()
+
+			go ws.readLoop(conn)
+			ws.resubscribe(subs)
+			return
+		}
+		delay *= 2
+		if delay > WS_RECONNECT_MAX_DELAY {
+			delay = WS_RECONNECT_MAX_DELAY
+		}
+	}
+}
+
+// resubscribe replays eth_subscribe for subs. A subscription the server
+// rejects is ended; one cut off by another connection drop is kept for
+// the next reconnect, which fails the call before dialing again.
+func (ws *WsClient) resubscribe(subs []*wsSubscription) {
+	for _, sub := range subs {
+		sub.mu.Lock()
+		ended := sub.ended
+		sub.mu.Unlock()
+		if ended {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), WS_RECONNECT_MAX_DELAY)
+		_, err := ws.subscribe(ctx, sub)
+		cancel()
+		if errors.Is(err, errWsDisconnected) {
+			ws.mu.Lock()
+			ws.resubs = append(ws.resubs, sub)
+			ws.mu.Unlock()
+			continue
+		}
+		if err != nil {
+			sub.end(err)
+		}
+		// the reader registered the new id, or dropped it when sub was
+		// unsubscribed meanwhile
+	}
+}
+
+// subscribe sends eth_subscribe for sub, the reader registers it under the
+// id returned by the server.
+func (ws *WsClient) subscribe(ctx context.Context, sub *wsSubscription) (string, error) {
+	raw, err := ws.callFor(ctx, sub, "eth_subscribe", sub.params)
+	if err != nil {
+		return "", err
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return "", &AlchemyClientError{"eth_subscribe", err.Error()}
+	}
+	return id, nil
+}
+
+// unsubscribe ends sub before looking up its id: an id the reader
+// registers afterwards, from a resubscription in flight, is dropped
+// instead.
+func (ws *WsClient) unsubscribe(ctx context.Context, sub *wsSubscription) error {
+	id, live := ws.remove(sub)
+	if !live {
+		return nil
+	}
+	raw, err := ws.Call(ctx, "eth_unsubscribe", []interface{}{id})
+	if err != nil {
+		return err
+	}
+	var ok bool
+	if err := json.Unmarshal(raw, &ok); err != nil {
+		return &AlchemyClientError{"eth_unsubscribe", err.Error()}
+	}
+	return nil
+}
+
+// remove ends sub and stops routing its events, it returns the server id
+// still live for it, if any.
+func (ws *WsClient) remove(sub *wsSubscription) (string, bool) {
+	sub.end(nil)
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	id := sub.getId()
+	if ws.subs[id] != sub {
+		return "", false
+	}
+	delete(ws.subs, id)
+	return id, true
+}
+
+// drop sends eth_unsubscribe for a server subscription id no longer
+// routed to any subscription.
+func (ws *WsClient) drop(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), WS_RECONNECT_MAX_DELAY)
+	defer cancel()
+	ws.Call(ctx, "eth_unsubscribe", []interface{}{id})
+}
@@ -0,0 +1,420 @@
+package goalchemysdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nabetse00/go-alchemy-sdk/types"
+)
+
+// fakeWsServer answers eth_subscribe with a fresh id and pushes one event
+// per subscription. The first connection is dropped right after its first
+// event so the client has to reconnect and resubscribe.
+func fakeWsServer(events map[string]string) *httptest.Server {
+	var mu sync.Mutex
+	connections := 0
+	subCounter := 0
+	upgrader := websocket.Upgrader{}
+	responseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		connections++
+		drop := connections == 1
+		mu.Unlock()
+
+		for {
+			var req JsonParams[json.RawMessage]
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			switch req.Method {
+			case "eth_subscribe":
+				var kind string
+				json.Unmarshal(req.Params[0], &kind)
+				mu.Lock()
+				subCounter++
+				id := fmt.Sprintf("0x%x", subCounter)
+				mu.Unlock()
+				conn.WriteJSON(AlchemyResponse[string]{Id: req.Id, Jsonrpc: "2.0", Result: id})
+				conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(
+					`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"%s","result":%s}}`,
+					id, events[kind])))
+				if drop {
+					return
+				}
+			case "eth_unsubscribe":
+				conn.WriteJSON(AlchemyResponse[bool]{Id: req.Id, Jsonrpc: "2.0", Result: true})
+			default:
+				conn.WriteJSON(AlchemyResponse[interface{}]{Id: req.Id, Jsonrpc: "2.0", Error: ErrorWrongMethod(req.Method)})
+			}
+		}
+	})
+	return httptest.NewServer(responseHandler)
+}
+
+func TestWsClient_SubscribeNewHeads_reconnect(t *testing.T) {
+	ts := fakeWsServer(map[string]string{
+		"newHeads": `{"number":"0x1b4","hash":"0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"}`,
+	})
+	defer ts.Close()
+
+	c := &AlchemyClient{ApiKey: "key", BaseUrlApiV2: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ws, err := c.DialWs(ctx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.DialWs() error = %v", err)
+	}
+	defer ws.Close()
+
+	sub, err := ws.SubscribeNewHeads(ctx)
+	if err != nil {
+		t.Fatalf("WsClient.SubscribeNewHeads() error = %v", err)
+	}
+	want := BlockHeader{Number: types.Uint64Quantity(0x1b4), Hash: types.MustParseHash("0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae")}
+	// one event before the connection drops, one after resubscription
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-sub.Events():
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("WsClient.SubscribeNewHeads() event = %v, want %v", got, want)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("WsClient.SubscribeNewHeads() subscription error = %v", err)
+		case <-ctx.Done():
+			t.Fatalf("WsClient.SubscribeNewHeads() event %d not received", i)
+		}
+	}
+	if id := sub.sub.getId(); id != "0x2" {
+		t.Errorf("WsClient.SubscribeNewHeads() id after resubscription = %v, want 0x2", id)
+	}
+
+	if err := sub.Unsubscribe(ctx); err != nil {
+		t.Errorf("Subscription.Unsubscribe() error = %v", err)
+	}
+	if _, open := <-sub.Err(); open {
+		t.Errorf("Subscription.Err() not closed after Unsubscribe")
+	}
+	if _, open := <-sub.Events(); open {
+		t.Errorf("Subscription.Events() not closed after Unsubscribe")
+	}
+}
+
+func TestWsClient_Unsubscribe_reconnecting(t *testing.T) {
+	resubscribing := make(chan struct{})
+	unsubscribed := make(chan struct{})
+	dropped := make(chan string, 1)
+	var mu sync.Mutex
+	connections := 0
+	upgrader := websocket.Upgrader{}
+	// the first connection drops after eth_subscribe, the second holds
+	// back its answer until the subscription is ended
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		connections++
+		n := connections
+		mu.Unlock()
+		for {
+			var req JsonParams[json.RawMessage]
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			switch req.Method {
+			case "eth_subscribe":
+				if n > 1 {
+					close(resubscribing)
+					<-unsubscribed
+				}
+				conn.WriteJSON(AlchemyResponse[string]{Id: req.Id, Jsonrpc: "2.0", Result: fmt.Sprintf("0x%x", n)})
+				if n == 1 {
+					return
+				}
+			case "eth_unsubscribe":
+				var id string
+				json.Unmarshal(req.Params[0], &id)
+				dropped <- id
+				conn.WriteJSON(AlchemyResponse[bool]{Id: req.Id, Jsonrpc: "2.0", Result: true})
+			}
+		}
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{ApiKey: "key", BaseUrlApiV2: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ws, err := c.DialWs(ctx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.DialWs() error = %v", err)
+	}
+	defer ws.Close()
+	sub, err := ws.SubscribeNewHeads(ctx)
+	if err != nil {
+		t.Fatalf("WsClient.SubscribeNewHeads() error = %v", err)
+	}
+
+	<-resubscribing
+	if err := sub.Unsubscribe(ctx); err != nil {
+		t.Errorf("Subscription.Unsubscribe() error = %v", err)
+	}
+	if _, open := <-sub.Events(); open {
+		t.Errorf("Subscription.Events() not closed after Unsubscribe")
+	}
+	close(unsubscribed)
+
+	select {
+	case id := <-dropped:
+		if id != "0x2" {
+			t.Errorf("eth_unsubscribe id = %s, want 0x2", id)
+		}
+	case <-ctx.Done():
+		t.Fatalf("resubscription answered after Unsubscribe not dropped")
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if len(ws.subs) != 0 {
+		t.Errorf("WsClient subscriptions = %v, want none", ws.subs)
+	}
+}
+
+func TestWsClient_SubscribeLogs(t *testing.T) {
+	ts := fakeWsServer(map[string]string{
+		"logs":                   `{"address":"0x2cde9919e81b20b4b33dd562a48a84b54c48f00c","topics":["0xa6faee2246474597b6de7c76bf9a45d256737543cb0806e6e805b55b38c7663f"],"logIndex":"0x2"}`,
+		"newPendingTransactions": `"0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3"`,
+	})
+	defer ts.Close()
+
+	c := &AlchemyClient{ApiKey: "key", BaseUrlApiV2: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ws, err := c.DialWs(ctx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.DialWs() error = %v", err)
+	}
+
+	// wait for the forced reconnection so both subscriptions share a connection
+	first, err := ws.SubscribeNewPendingTransactions(ctx)
+	if err != nil {
+		t.Fatalf("WsClient.SubscribeNewPendingTransactions() error = %v", err)
+	}
+	if got := <-first.Events(); got != types.MustParseHash("0xc37715b1d976e1133f1f62ab3dd856d41be3bb4fe8d4091f3c7849769d041ad3") {
+		t.Errorf("WsClient.SubscribeNewPendingTransactions() event = %v", got)
+	}
+	<-first.Events()
+
+	address := types.MustParseAddress("0x2cde9919e81b20b4b33dd562a48a84b54c48f00c")
+	logs, err := ws.SubscribeLogs(ctx, LogsParam{Address: &address})
+	if err != nil {
+		t.Fatalf("WsClient.SubscribeLogs() error = %v", err)
+	}
+	select {
+	case got := <-logs.Events():
+		if got.Address != address || got.LogIndex.Uint64() != 2 || len(got.Topics) != 1 {
+			t.Errorf("WsClient.SubscribeLogs() event = %v", got)
+		}
+	case <-ctx.Done():
+		t.Fatalf("WsClient.SubscribeLogs() event not received")
+	}
+
+	ws.Close()
+	if err, open := <-logs.Err(); !open || err != ErrWsClosed {
+		t.Errorf("Subscription.Err() = %v, want %v", err, ErrWsClosed)
+	}
+	for range logs.Events() {
+	}
+	if _, err := ws.SubscribeNewHeads(ctx); err != ErrWsClosed {
+		t.Errorf("WsClient.SubscribeNewHeads() on closed client error = %v, want %v", err, ErrWsClosed)
+	}
+}
+
+// answeredCtx expires as soon as the reader registered a subscription,
+// once the server answered eth_subscribe.
+type answeredCtx struct {
+	context.Context
+	ws *WsClient
+}
+
+func (c answeredCtx) Done() <-chan struct{} {
+	for i := 0; i < 1000; i++ {
+		c.ws.mu.Lock()
+		registered := len(c.ws.subs) > 0
+		c.ws.mu.Unlock()
+		if registered {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func (c answeredCtx) Err() error {
+	return context.DeadlineExceeded
+}
+
+func TestWsClient_Subscribe_expired(t *testing.T) {
+	dropped := make(chan string, 100)
+	upgrader := websocket.Upgrader{}
+	// every eth_subscribe answer is followed by more events than a
+	// subscription buffers
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		subCounter := 0
+		for {
+			var req JsonParams[json.RawMessage]
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			switch req.Method {
+			case "eth_subscribe":
+				subCounter++
+				id := fmt.Sprintf("0x%x", subCounter)
+				conn.WriteJSON(AlchemyResponse[string]{Id: req.Id, Jsonrpc: "2.0", Result: id})
+				for i := 0; i < 20; i++ {
+					conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"`+id+`","result":{"number":"0x1"}}}`))
+				}
+			case "eth_unsubscribe":
+				var id string
+				json.Unmarshal(req.Params[0], &id)
+				dropped <- id
+				conn.WriteJSON(AlchemyResponse[bool]{Id: req.Id, Jsonrpc: "2.0", Result: true})
+			default:
+				conn.WriteJSON(AlchemyResponse[string]{Id: req.Id, Jsonrpc: "2.0", Result: "0x1"})
+			}
+		}
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{ApiKey: "key", BaseUrlApiV2: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ws, err := c.DialWs(ctx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.DialWs() error = %v", err)
+	}
+	defer ws.Close()
+
+	// the answer and the expired ctx race, until the ctx wins
+	for i := 0; ; i++ {
+		if i == 100 {
+			t.Fatalf("WsClient.SubscribeNewHeads() never expired")
+		}
+		sub, err := ws.SubscribeNewHeads(answeredCtx{ctx, ws})
+		if err == nil {
+			sub.Unsubscribe(ctx)
+			<-dropped
+			continue
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("WsClient.SubscribeNewHeads() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		break
+	}
+	ws.mu.Lock()
+	subs := len(ws.subs)
+	ws.mu.Unlock()
+	if subs != 0 {
+		t.Errorf("WsClient subscriptions after an expired subscribe = %d, want none", subs)
+	}
+	select {
+	case <-dropped:
+	case <-ctx.Done():
+		t.Fatalf("expired subscription not unsubscribed")
+	}
+	// the reader is not stalled by the events of the expired subscription
+	if _, err := ws.Call(ctx, "eth_chainId", nil); err != nil {
+		t.Errorf("WsClient.Call() error = %v", err)
+	}
+}
+
+func TestWsClient_reconnect_dropped_while_resubscribing(t *testing.T) {
+	var mu sync.Mutex
+	connections, subCounter := 0, 0
+	upgrader := websocket.Upgrader{}
+	// the first connection drops after two subscriptions, the second on
+	// the first resubscription, the third answers them
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		connections++
+		n := connections
+		mu.Unlock()
+		for {
+			var req JsonParams[json.RawMessage]
+			if err := conn.ReadJSON(&req); err != nil || n == 2 {
+				return
+			}
+			mu.Lock()
+			subCounter++
+			count := subCounter
+			mu.Unlock()
+			id := fmt.Sprintf("0x%x", count)
+			conn.WriteJSON(AlchemyResponse[string]{Id: req.Id, Jsonrpc: "2.0", Result: id})
+			if n == 1 && count == 2 {
+				return
+			}
+			if n == 3 {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"`+id+`","result":{"number":"0x1"}}}`))
+			}
+		}
+	}))
+	defer ts.Close()
+
+	c := &AlchemyClient{ApiKey: "key", BaseUrlApiV2: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ws, err := c.DialWs(ctx)
+	if err != nil {
+		t.Fatalf("AlchemyClient.DialWs() error = %v", err)
+	}
+	defer ws.Close()
+
+	var subs []*Subscription[BlockHeader]
+	for i := 0; i < 2; i++ {
+		sub, err := ws.SubscribeNewHeads(ctx)
+		if err != nil {
+			t.Fatalf("WsClient.SubscribeNewHeads() error = %v", err)
+		}
+		subs = append(subs, sub)
+	}
+	for i, sub := range subs {
+		select {
+		case got := <-sub.Events():
+			if got.Number.Uint64() != 1 {
+				t.Errorf("subscription %d event = %v", i, got)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription %d ended, error = %v", i, err)
+		case <-ctx.Done():
+			t.Fatalf("subscription %d event not received", i)
+		}
+	}
+}